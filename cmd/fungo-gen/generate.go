@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"strings"
+	"unicode"
+)
+
+// generator writes the generated code for every struct into a buffer.
+type generator struct {
+	buf bytes.Buffer
+	pkg string
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// generate returns the formatted source of a file that implements Model for
+// every given struct.
+func generate(pkg string, args string, structs []structType) ([]byte, error) {
+	g := &generator{pkg: pkg}
+	g.printf("// Code generated by \"fungo-gen %s\"; DO NOT EDIT.\n\n", args)
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n\t\"fmt\"\n\n\t\"github.com/Mathew-Estafanous/funGo/model\"\n)\n")
+
+	for _, st := range structs {
+		recv := receiverName(st.name)
+		g.equals(st, recv)
+		g.hash(st, recv)
+		g.compare(st, recv)
+		g.string(st, recv)
+		g.accessors(st)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// reserved are the names used by the generated code, which the receiver
+// must not shadow.
+var reserved = map[string]bool{"m": true, "h": true, "c": true, "i": true}
+
+// receiverName returns a short receiver name for the type, in the same way
+// that the models of this library are named.
+func receiverName(name string) string {
+	runes := []rune(name)
+	recv := string(unicode.ToLower(runes[0]))
+	if reserved[recv] || !unicode.IsLetter(runes[0]) {
+		recv = "v"
+	}
+	return recv
+}
+
+// modelOf returns the expression that converts the value of a field into
+// a Model.
+func modelOf(f field, value string) string {
+	if f.kind == kindOther {
+		return fmt.Sprintf("model.Of(%s)", value)
+	}
+	return fmt.Sprintf("model.%s(%s)", f.model, value)
+}
+
+// differExpr returns the expression that checks whether the two values of
+// a field, which is neither a slice nor a pointer, are different.
+func differExpr(f field, a, b string) string {
+	switch f.kind {
+	case kindOrdered, kindModelOrdered:
+		return fmt.Sprintf("%s != %s", a, b)
+	case kindTime:
+		return fmt.Sprintf("!%s.Equal(%s)", a, b)
+	default:
+		return fmt.Sprintf("!model.ModelsEqual(model.Of(%s), model.Of(%s))", a, b)
+	}
+}
+
+// hashExpr returns the expression that hashes the value of a field, which
+// is neither a slice nor a pointer.
+func hashExpr(f field, value string) string {
+	if f.kind == kindOther {
+		return fmt.Sprintf("model.HashModel(%s)", modelOf(f, value))
+	}
+	return modelOf(f, value) + ".Hash()"
+}
+
+// article returns the indefinite article to use before the name.
+func article(name string) string {
+	if strings.ContainsRune("AEIOU", []rune(name)[0]) {
+		return "an"
+	}
+	return "a"
+}
+
+func (g *generator) equals(st structType, recv string) {
+	g.printf("\n// Equals checks and returns 'true' if m is %s %s with equal fields.\n", article(st.name), st.name)
+	g.printf("func (%s %s) Equals(m model.Model) bool {\n", recv, st.name)
+	g.printf("other, ok := m.(%s)\nif !ok {\nreturn false\n}\n", st.name)
+
+	for _, f := range st.fields {
+		a, b := recv+"."+f.name, "other."+f.name
+		switch f.kind {
+		case kindSlice:
+			g.printf("if len(%s) != len(%s) {\nreturn false\n}\n", a, b)
+			g.printf("for i := range %s {\nif %s[i] != %s[i] {\nreturn false\n}\n}\n", a, a, b)
+		case kindPointer:
+			g.printf("if (%s == nil) != (%s == nil) {\nreturn false\n}\n", a, b)
+			g.printf("if %s != nil && %s {\nreturn false\n}\n", a, differExpr(*f.elem, "*"+a, "*"+b))
+		default:
+			g.printf("if %s {\nreturn false\n}\n", differExpr(f, a, b))
+		}
+	}
+	g.printf("return true\n}\n")
+}
+
+func (g *generator) hash(st structType, recv string) {
+	g.printf("\n// Hash returns the hash of %s, which is the same for every equal %s.\n", recv, st.name)
+	g.printf("func (%s %s) Hash() uint64 {\n", recv, st.name)
+	g.printf("h := model.NewHashBuilder(%q)\n", g.pkg+"."+st.name)
+
+	for _, f := range st.fields {
+		value := recv + "." + f.name
+		switch f.kind {
+		case kindSlice:
+			g.printf("h = h.Add(uint64(len(%s)))\n", value)
+			g.printf("for _, elem := range %s {\nh = h.Add(%s)\n}\n", value, hashExpr(f, "elem"))
+		case kindPointer:
+			g.printf("if %s != nil {\nh = h.Add(%s)\n} else {\nh = h.Add(0)\n}\n", value, hashExpr(*f.elem, "*"+value))
+		default:
+			g.printf("h = h.Add(%s)\n", hashExpr(f, value))
+		}
+	}
+	g.printf("return h.Sum()\n}\n")
+}
+
+// compare is only generated when at least one field has been tagged with
+// `fungo:"order"` or `fungo:"desc"`.
+func (g *generator) compare(st structType, recv string) {
+	var order []string
+	for _, f := range st.fields {
+		if f.order {
+			name := f.name
+			if f.desc {
+				name += " (descending)"
+			}
+			order = append(order, name)
+		}
+	}
+	if len(order) == 0 {
+		return
+	}
+
+	g.printf("\n// Compare returns -1, 0 or 1 depending on whether %s is ordered before, the\n", recv)
+	g.printf("// same as or after the %s m, by %s.\n", st.name, strings.Join(order, " then "))
+	g.printf("func (%s %s) Compare(m model.Model) int {\n", recv, st.name)
+	g.printf("other, ok := m.(%s)\nif !ok {\n", st.name)
+	g.printf("panic(fmt.Sprintf(\"model: cannot compare %%T with %%T\", %s, m))\n}\n", recv)
+
+	for _, f := range st.fields {
+		if !f.order {
+			continue
+		}
+
+		less, greater, sign := "-1", "1", ""
+		if f.desc {
+			less, greater, sign = greater, less, "-"
+		}
+
+		a, b := recv+"."+f.name, "other."+f.name
+		switch f.kind {
+		case kindOrdered:
+			g.printf("if %s != %s {\nif %s < %s {\nreturn %s\n}\nreturn %s\n}\n", a, b, a, b, less, greater)
+		case kindOther:
+			g.printf("if c := model.CompareModels(%s, %s); c != 0 {\nreturn %sc\n}\n",
+				modelOf(f, a), modelOf(f, b), sign)
+		default:
+			g.printf("if c := %s.Compare(%s); c != 0 {\nreturn %sc\n}\n",
+				modelOf(f, a), modelOf(f, b), sign)
+		}
+	}
+	g.printf("return 0\n}\n")
+}
+
+func (g *generator) string(st structType, recv string) {
+	var formats, values []string
+	for _, f := range st.fields {
+		formats = append(formats, f.name+": %v")
+		values = append(values, recv+"."+f.name)
+	}
+
+	g.printf("\n// String returns %s formatted with the name and value of each field.\n", recv)
+	g.printf("func (%s %s) String() string {\n", recv, st.name)
+	g.printf("return fmt.Sprintf(%q", st.name+"{"+strings.Join(formats, ", ")+"}")
+	for _, v := range values {
+		g.printf(", %s", v)
+	}
+	g.printf(")\n}\n")
+}
+
+// accessors generates a function for every exported field that returns the
+// value of that field as a Model, which can be used as an Operator.
+func (g *generator) accessors(st structType) {
+	for _, f := range st.fields {
+		if !ast.IsExported(f.name) {
+			continue
+		}
+
+		name := st.name + "By" + f.name
+		g.printf("\n// %s returns the %s field of %s %s as a Model, so that it\n", name, f.name, article(st.name), st.name)
+		g.printf("// can be used as an Operator.\n")
+		g.printf("func %s(m model.Model) model.Model {\n", name)
+
+		value := "m.(" + st.name + ")." + f.name
+		switch f.kind {
+		case kindSlice:
+			g.printf("values := %s\nslice := make(model.ModelSlice, len(values))\n", value)
+			g.printf("for i, e := range values {\nslice[i] = %s\n}\nreturn slice\n}\n", modelOf(f, "e"))
+		case kindPointer:
+			g.printf("if p := %s; p != nil {\nreturn %s\n}\nreturn nil\n}\n", value, modelOf(*f.elem, "*p"))
+		default:
+			g.printf("return %s\n}\n", modelOf(f, value))
+		}
+	}
+}
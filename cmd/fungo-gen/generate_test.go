@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateFile_Golden(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	output := filepath.Join(dir, "employee_model.go")
+
+	want, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := generateFile(dir, []string{"Employee", "Team"}, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("Generated code does not match %v, run go generate within %v.", output, dir)
+	}
+}
+
+func TestGenerateFile_Errors(t *testing.T) {
+	type test struct {
+		name   string
+		source string
+		want   string
+	}
+
+	table := []test{
+		{
+			name:   "A type that does not exist should return an error.",
+			source: "package p\n\ntype Other struct{}\n",
+			want:   "struct type Employee not found",
+		},
+		{
+			name:   "An ordered slice field should return an error.",
+			source: "package p\n\ntype Employee struct {\n\tTags []string `fungo:\"order\"`\n}\n",
+			want:   "field Employee.Tags cannot be ordered",
+		},
+	}
+
+	for _, te := range table {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(te.source), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := generateFile(dir, []string{"Employee"}, filepath.Join(dir, "employee_model.go"))
+		if err == nil || !strings.Contains(err.Error(), te.want) {
+			t.Errorf("%v Got error %v.", te.name, err)
+		}
+	}
+}
+
+func TestReceiverName(t *testing.T) {
+	type test struct {
+		name string
+		want string
+	}
+
+	table := []test{
+		{name: "Employee", want: "e"},
+		{name: "Manager", want: "v"},
+		{name: "Hours", want: "v"},
+	}
+
+	for _, te := range table {
+		if recv := receiverName(te.name); recv != te.want {
+			t.Errorf("Expected the receiver of %v to be %v but got %v.", te.name, te.want, recv)
+		}
+	}
+}
+
+func TestRun_AbsoluteOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "employee_model.go")
+	if err := run(filepath.Join("internal", "example"), []string{"Employee", "Team"}, output); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("internal", "example", "employee_model.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Expected the code to be written to %v but got %v.", output, err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Code written to %v does not match the generated code.", output)
+	}
+}
@@ -0,0 +1,30 @@
+// Package example holds the types that fungo-gen is tested against, along
+// with the code that it generated for them.
+package example
+
+import "time"
+
+//go:generate go run ../.. -type=Employee,Team
+
+// Title is the job title of an Employee.
+type Title string
+
+// Employee is ordered by title, and then by salary from the highest to the
+// lowest.
+type Employee struct {
+	Name    string
+	Title   Title   `fungo:"order"`
+	Salary  float64 `fungo:"desc"`
+	Skills  []string
+	Started time.Time
+	Notice  time.Duration
+	Manager *Employee
+	id      int
+	cache   map[string]int `fungo:"-"`
+}
+
+// Team is a group of employees.
+type Team struct {
+	Name    string `fungo:"order"`
+	Members []Employee
+}
@@ -0,0 +1,108 @@
+package example
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+	. "github.com/Mathew-Estafanous/funGo/stream"
+)
+
+func newEmployee(name string, title Title, salary float64) Employee {
+	return Employee{
+		Name:    name,
+		Title:   title,
+		Salary:  salary,
+		Skills:  []string{"go"},
+		Started: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Manager: &Employee{Name: "Kim"},
+	}
+}
+
+func TestEmployee_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]Employee
+		want   bool
+	}
+
+	alex := newEmployee("Alex", "Engineer", 100)
+	other := newEmployee("Alex", "Engineer", 100)
+	other.Started = other.Started.In(time.FixedZone("EST", -5*60*60))
+	other.cache = map[string]int{"ignored": 1}
+
+	newManager := newEmployee("Alex", "Engineer", 100)
+	newManager.Manager = &Employee{Name: "Sam"}
+
+	noManager := newEmployee("Alex", "Engineer", 100)
+	noManager.Manager = nil
+
+	table := []test{
+		{
+			name:   "Employees with equal fields in a different location and cache, should return true.",
+			models: [2]Employee{alex, other},
+			want:   true,
+		},
+		{
+			name:   "Employees with different managers, should return false.",
+			models: [2]Employee{alex, newManager},
+			want:   false,
+		},
+		{
+			name:   "Only one employee has a manager, should return false.",
+			models: [2]Employee{alex, noManager},
+			want:   false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want || model2.Equals(model1) != te.want {
+			t.Error(te.name)
+		}
+		if te.want && model1.Hash() != model2.Hash() {
+			t.Errorf("%v Equal employees should have the same hash.", te.name)
+		}
+	}
+}
+
+func TestEmployee_Compare(t *testing.T) {
+	employees := ModelSlice{
+		newEmployee("Alex", "Manager", 100),
+		newEmployee("Sam", "Engineer", 90),
+		newEmployee("Kim", "Engineer", 120),
+	}
+
+	result := Collect(NewStreamFromSlice(employees).Sorted().Map(EmployeeByName), ToSlice())
+	want := ModelSlice{ModelString("Kim"), ModelString("Sam"), ModelString("Alex")}
+
+	if !result.Equals(want) {
+		t.Errorf("Expected employees sorted by title then salary %v but got %v.", want, result)
+	}
+}
+
+func TestEmployeeByTitle(t *testing.T) {
+	employees := ModelSlice{
+		newEmployee("Alex", "Manager", 100),
+		newEmployee("Sam", "Engineer", 90),
+		newEmployee("Kim", "Engineer", 120),
+	}
+
+	result := Collect(NewStreamFromSlice(employees), GroupingBy(EmployeeByTitle, Counting()))
+	want := ModelMap{ModelString("Manager"): ModelInt(1), ModelString("Engineer"): ModelInt(2)}
+
+	if !result.Equals(want) {
+		t.Errorf("Expected employees grouped by title %v but got %v.", want, result)
+	}
+}
+
+func TestEmployeeBySkills(t *testing.T) {
+	result := EmployeeBySkills(Employee{Skills: []string{"go", "sql"}})
+	if !result.Equals(ModelSlice{ModelString("go"), ModelString("sql")}) {
+		t.Errorf("Expected the skills as a ModelSlice but got %v.", result)
+	}
+
+	if manager := EmployeeByManager(Employee{}); manager != nil {
+		t.Errorf("Expected a nil manager to be returned as a nil model but got %v.", manager)
+	}
+}
@@ -0,0 +1,85 @@
+// Command fungo-gen generates Model implementations for struct types, so
+// that they can be used within a Stream without reflection or hand-written
+// methods. It is meant to be run by go generate:
+//
+//	//go:generate go run github.com/Mathew-Estafanous/funGo/cmd/fungo-gen -type=Employee
+//
+// For every struct type it generates the Equals, Hash and String methods,
+// along with a function for every exported field, such as EmployeeByTitle,
+// that returns the field as a Model and can be used as an Operator.
+//
+// A Compare method, which implements model.Ordered, is generated when any
+// field is tagged with `fungo:"order"`, or `fungo:"desc"` for a descending
+// order. The fields are compared in the order they are declared. Fields
+// tagged with `fungo:"-"` are ignored.
+//
+// Fields of a basic type, time.Time, time.Duration and slices of a basic
+// type are handled without reflection. Every other field is converted with
+// model.Of, which only uses reflection when the type does not implement
+// Model.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name, relative to -dir unless absolute; default <type>_model.go")
+	dir := flag.String("dir", ".", "directory of the package containing the types")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: fungo-gen -type=T[,T...] [-output=file] [-dir=directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*dir, strings.Split(*typeNames, ","), *output); err != nil {
+		fmt.Fprintf(os.Stderr, "fungo-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the code for the types within dir and writes it to the
+// output file. A relative output is within dir.
+func run(dir string, types []string, output string) error {
+	if output == "" {
+		output = strings.ToLower(types[0]) + "_model.go"
+	}
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+
+	src, err := generateFile(dir, types, output)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, src, 0644)
+}
+
+// generateFile returns the generated source for the types within dir.
+func generateFile(dir string, types []string, output string) ([]byte, error) {
+	info, err := parsePackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := make([]structType, 0, len(types))
+	for _, name := range types {
+		st, err := info.lookup(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, st)
+	}
+
+	return generate(info.name, "-type="+strings.Join(types, ","), structs)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldKind decides how a field is compared, hashed and converted into a
+// Model by the generated code.
+type fieldKind int
+
+const (
+	// kindOrdered fields are compared with == and ordered with <.
+	kindOrdered fieldKind = iota
+	// kindModelOrdered fields are compared with == and ordered using the
+	// Compare method of their model, such as floats which order NaN first.
+	kindModelOrdered
+	// kindTime fields are time.Time values that are compared with Equal.
+	kindTime
+	// kindSlice fields are slices of a basic type.
+	kindSlice
+	// kindPointer fields are pointers, which are equal when both are nil
+	// or both point to equal values.
+	kindPointer
+	// kindOther fields are converted with model.Of, which only uses
+	// reflection when the type does not implement Model.
+	kindOther
+)
+
+// basicModels maps each basic Go type onto the model it is converted into.
+var basicModels = map[string]struct {
+	model string
+	kind  fieldKind
+}{
+	"string":  {"ModelString", kindOrdered},
+	"int":     {"ModelInt", kindOrdered},
+	"int8":    {"ModelInt", kindOrdered},
+	"int16":   {"ModelInt", kindOrdered},
+	"int32":   {"ModelInt64", kindOrdered},
+	"int64":   {"ModelInt64", kindOrdered},
+	"rune":    {"ModelRune", kindOrdered},
+	"uint":    {"ModelUint64", kindOrdered},
+	"uint16":  {"ModelUint64", kindOrdered},
+	"uint32":  {"ModelUint64", kindOrdered},
+	"uint64":  {"ModelUint64", kindOrdered},
+	"uintptr": {"ModelUint64", kindOrdered},
+	"uint8":   {"ModelByte", kindOrdered},
+	"byte":    {"ModelByte", kindOrdered},
+	"float32": {"ModelFloat", kindModelOrdered},
+	"float64": {"ModelFloat64", kindModelOrdered},
+	"bool":    {"ModelBool", kindModelOrdered},
+}
+
+// field is a single field of a struct that the code is generated for.
+type field struct {
+	name  string
+	kind  fieldKind
+	model string
+	elem  *field
+	order bool
+	desc  bool
+}
+
+// structType is a struct that the code is generated for.
+type structType struct {
+	name   string
+	fields []field
+}
+
+// pkgInfo holds everything that was read from the package's source files.
+type pkgInfo struct {
+	name    string
+	structs map[string]*ast.StructType
+	imports map[*ast.StructType]map[string]string
+	basics  map[string]string
+}
+
+// parsePackage reads every Go file within dir, except for test files and
+// the file that is being generated.
+func parsePackage(dir, output string) (*pkgInfo, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &pkgInfo{
+		structs: map[string]*ast.StructType{},
+		imports: map[*ast.StructType]map[string]string{},
+		basics:  map[string]string{},
+	}
+	named := map[string]ast.Expr{}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == filepath.Base(output) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		info.name = file.Name.Name
+
+		imports := fileImports(file)
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					info.structs[ts.Name.Name] = st
+					info.imports[st] = imports
+				} else if !ts.Assign.IsValid() {
+					named[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+
+	if info.name == "" {
+		return nil, fmt.Errorf("no Go files found in %s", dir)
+	}
+
+	for name := range named {
+		if basic, ok := resolveBasic(name, named, 0); ok {
+			info.basics[name] = basic
+		}
+	}
+	return info, nil
+}
+
+// resolveBasic follows the named type until it reaches a basic type, such
+// as the string of 'type Title string'.
+func resolveBasic(name string, named map[string]ast.Expr, depth int) (string, bool) {
+	if _, ok := basicModels[name]; ok {
+		return name, true
+	}
+	expr, ok := named[name]
+	if !ok || depth > len(named) {
+		return "", false
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return resolveBasic(ident.Name, named, depth+1)
+}
+
+// fileImports maps the name that each package is imported as onto its path.
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// lookup finds the struct with the given name and reads its fields.
+func (info *pkgInfo) lookup(name string) (structType, error) {
+	st, ok := info.structs[name]
+	if !ok {
+		return structType{}, fmt.Errorf("struct type %s not found in package %s", name, info.name)
+	}
+
+	result := structType{name: name}
+	for _, f := range st.Fields.List {
+		names := f.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{embeddedName(f.Type)}
+		}
+
+		tag := ""
+		if f.Tag != nil {
+			if unquoted, err := strconv.Unquote(f.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted).Get("fungo")
+			}
+		}
+		if tag == "-" {
+			continue
+		}
+
+		for _, ident := range names {
+			if ident == nil || ident.Name == "_" {
+				continue
+			}
+
+			fd := info.classify(f.Type, info.imports[st])
+			fd.name = ident.Name
+			for _, opt := range strings.Split(tag, ",") {
+				switch opt {
+				case "order":
+					fd.order = true
+				case "desc":
+					fd.order, fd.desc = true, true
+				}
+			}
+			if fd.order && (fd.kind == kindSlice || fd.kind == kindPointer) {
+				return structType{}, fmt.Errorf("field %s.%s cannot be ordered", name, fd.name)
+			}
+			result.fields = append(result.fields, fd)
+		}
+	}
+	return result, nil
+}
+
+// classify decides the kind of the field from its type expression.
+func (info *pkgInfo) classify(expr ast.Expr, imports map[string]string) field {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		basic := t.Name
+		if resolved, ok := info.basics[t.Name]; ok {
+			basic = resolved
+		}
+		if b, ok := basicModels[basic]; ok {
+			return field{kind: b.kind, model: b.model}
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && imports[pkg.Name] == "time" {
+			switch t.Sel.Name {
+			case "Time":
+				return field{kind: kindTime, model: "ModelTime"}
+			case "Duration":
+				return field{kind: kindOrdered, model: "ModelDuration"}
+			}
+		}
+	case *ast.StarExpr:
+		elem := info.classify(t.X, imports)
+		if elem.kind == kindSlice || elem.kind == kindPointer {
+			elem = field{kind: kindOther}
+		}
+		return field{kind: kindPointer, elem: &elem}
+	case *ast.ArrayType:
+		if t.Len == nil {
+			if elem := info.classify(t.Elt, imports); elem.kind == kindOrdered || elem.kind == kindModelOrdered {
+				return field{kind: kindSlice, model: elem.model}
+			}
+		}
+	}
+	return field{kind: kindOther}
+}
+
+// embeddedName returns the name of an embedded field, which is the name of
+// its type.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	}
+	return nil
+}
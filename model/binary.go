@@ -0,0 +1,454 @@
+package model
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// The tags are written before every encoded model and decide how the rest of
+// the model is read. New tags must only ever be added to the end.
+const (
+	binaryNil byte = iota
+	binaryInt
+	binaryInt64
+	binaryUint64
+	binaryByte
+	binaryFloat
+	binaryFloat64
+	binaryString
+	binaryRune
+	binaryBoolFalse
+	binaryBoolTrue
+	binaryTime
+	binaryDuration
+	binarySlice
+	binaryMap
+	binaryLinkedMap
+	binarySet
+	binaryHashMap
+	binaryHashSet
+	binaryRegistered
+	binaryPair
+	binaryTuple
+)
+
+// maxBinaryDepth limits how deeply nested the models read by Decode can be.
+const maxBinaryDepth = 10000
+
+// ErrBinaryDepth is returned by Decode when the models are nested more
+// deeply than it allows.
+var ErrBinaryDepth = errors.New("model: binary models are nested too deeply")
+
+// Encode writes m to w using a compact binary format, which can be read back
+// with Decode. Every built-in model is supported, including nested maps,
+// slices and sets, along with any model whose type was registered with
+// RegisterType. A registered model is written using its MarshalBinary method
+// if it implements encoding.BinaryMarshaler, and otherwise using its JSON
+// encoding.
+//
+// An error is returned for a ModelMap whose key is not accepted by IsMapKey,
+// such as a ModelSet, since it would not decode back into an equal map.
+//
+// Models are written one after another, so several can be written to the
+// same writer and read back with Decode in the same order.
+func Encode(w io.Writer, m Model) error {
+	buf, err := appendBinary(nil, m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// Decode reads a single model that was written by Encode. If r does not
+// implement io.ByteReader, it is wrapped in a bufio.Reader, which may read
+// past the end of the model. To decode several models from the same reader,
+// wrap it in a bufio.Reader first.
+//
+// A registered model is decoded into the type registered under its name,
+// and an error is returned if that name is not registered.
+func Decode(r io.Reader) (Model, error) {
+	br, ok := r.(binaryReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return readBinary(br, 0)
+}
+
+// binaryReader is the reader that every model is read from.
+type binaryReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	return append(buf, tmp[:binary.PutUvarint(tmp[:], v)]...)
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	return append(buf, tmp[:binary.PutVarint(tmp[:], v)]...)
+}
+
+func appendBinaryBytes(buf []byte, b []byte) []byte {
+	return append(appendUvarint(buf, uint64(len(b))), b...)
+}
+
+// appendBinary appends the binary encoding of the model to buf.
+func appendBinary(buf []byte, m Model) ([]byte, error) {
+	var err error
+	switch value := m.(type) {
+	case nil:
+		return append(buf, binaryNil), nil
+	case ModelInt:
+		return appendVarint(append(buf, binaryInt), int64(value)), nil
+	case ModelInt64:
+		return appendVarint(append(buf, binaryInt64), int64(value)), nil
+	case ModelUint64:
+		return appendUvarint(append(buf, binaryUint64), uint64(value)), nil
+	case ModelByte:
+		return append(buf, binaryByte, byte(value)), nil
+	case ModelFloat:
+		var data [4]byte
+		binary.LittleEndian.PutUint32(data[:], math.Float32bits(float32(value)))
+		return append(append(buf, binaryFloat), data[:]...), nil
+	case ModelFloat64:
+		var data [8]byte
+		binary.LittleEndian.PutUint64(data[:], math.Float64bits(float64(value)))
+		return append(append(buf, binaryFloat64), data[:]...), nil
+	case ModelString:
+		return appendBinaryBytes(append(buf, binaryString), []byte(value)), nil
+	case ModelRune:
+		return appendVarint(append(buf, binaryRune), int64(value)), nil
+	case ModelBool:
+		if value {
+			return append(buf, binaryBoolTrue), nil
+		}
+		return append(buf, binaryBoolFalse), nil
+	case ModelTime:
+		data, err := time.Time(value).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return appendBinaryBytes(append(buf, binaryTime), data), nil
+	case ModelDuration:
+		return appendVarint(append(buf, binaryDuration), int64(value)), nil
+	case ModelSlice:
+		return appendBinarySlice(append(buf, binarySlice), value)
+	case ModelMap:
+		buf = appendUvarint(append(buf, binaryMap), uint64(len(value)))
+		for k, v := range value {
+			if !IsMapKey(k) {
+				return nil, fmt.Errorf("model: cannot encode %T as a ModelMap key", k)
+			}
+			if buf, err = appendBinaryPair(buf, k, v); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case *ModelLinkedMap:
+		buf = appendUvarint(append(buf, binaryLinkedMap), uint64(value.Len()))
+		value.ForEach(func(k, v Model) {
+			if err == nil {
+				buf, err = appendBinaryPair(buf, k, v)
+			}
+		})
+		return buf, err
+	case *ModelSet:
+		return appendBinarySlice(append(buf, binarySet), value.Slice())
+	case *HashMap:
+		buf = appendUvarint(append(buf, binaryHashMap), uint64(value.Len()))
+		value.ForEach(func(k, v Model) {
+			if err == nil {
+				buf, err = appendBinaryPair(buf, k, v)
+			}
+		})
+		return buf, err
+	case *HashSet:
+		return appendBinarySlice(append(buf, binaryHashSet), value.Slice())
+	case ModelPair:
+		return appendBinaryPair(append(buf, binaryPair), value.First, value.Second)
+	case ModelTuple:
+		return appendBinarySlice(append(buf, binaryTuple), value.Slice())
+	default:
+		return appendBinaryRegistered(buf, m)
+	}
+}
+
+func appendBinarySlice(buf []byte, slice ModelSlice) ([]byte, error) {
+	buf = appendUvarint(buf, uint64(len(slice)))
+	for _, elem := range slice {
+		var err error
+		if buf, err = appendBinary(buf, elem); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func appendBinaryPair(buf []byte, k, v Model) ([]byte, error) {
+	buf, err := appendBinary(buf, k)
+	if err != nil {
+		return nil, err
+	}
+	return appendBinary(buf, v)
+}
+
+func appendBinaryRegistered(buf []byte, m Model) ([]byte, error) {
+	name, ok := registeredName(m)
+	if !ok {
+		return nil, fmt.Errorf("model: cannot encode %T, as its type is not registered", m)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if marshaler, ok := m.(encoding.BinaryMarshaler); ok {
+		data, err = marshaler.MarshalBinary()
+	} else {
+		data, err = json.Marshal(m)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	buf = appendBinaryBytes(append(buf, binaryRegistered), []byte(name))
+	return appendBinaryBytes(buf, data), nil
+}
+
+// readBinaryBytes reads a length followed by that many bytes. The bytes are
+// read in chunks, so that a corrupt length does not allocate more memory
+// than the reader holds.
+func readBinaryBytes(r binaryReader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	const chunk = 4096
+	var data []byte
+	for uint64(len(data)) < n {
+		size := n - uint64(len(data))
+		if size > chunk {
+			size = chunk
+		}
+		start := len(data)
+		data = append(data, make([]byte, size)...)
+		if _, err := io.ReadFull(r, data[start:]); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// readBinaryLen reads the number of models within a slice, map or set.
+func readBinaryLen(r binaryReader) (int, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxInt32 {
+		return 0, fmt.Errorf("model: invalid binary length %d", n)
+	}
+	return int(n), nil
+}
+
+// readBinary reads the next model from r. It returns io.EOF only when r
+// is empty, and io.ErrUnexpectedEOF when the model is cut short.
+func readBinary(r binaryReader, depth int) (Model, error) {
+	if depth > maxBinaryDepth {
+		return nil, ErrBinaryDepth
+	}
+
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := readBinaryValue(r, tag, depth)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return m, err
+}
+
+// readBinaryValue reads the rest of the model that the tag was read for.
+func readBinaryValue(r binaryReader, tag byte, depth int) (Model, error) {
+	switch tag {
+	case binaryNil:
+		return nil, nil
+	case binaryInt:
+		v, err := binary.ReadVarint(r)
+		return ModelInt(v), err
+	case binaryInt64:
+		v, err := binary.ReadVarint(r)
+		return ModelInt64(v), err
+	case binaryUint64:
+		v, err := binary.ReadUvarint(r)
+		return ModelUint64(v), err
+	case binaryByte:
+		v, err := r.ReadByte()
+		return ModelByte(v), err
+	case binaryFloat:
+		var data [4]byte
+		_, err := io.ReadFull(r, data[:])
+		return ModelFloat(math.Float32frombits(binary.LittleEndian.Uint32(data[:]))), err
+	case binaryFloat64:
+		var data [8]byte
+		_, err := io.ReadFull(r, data[:])
+		return ModelFloat64(math.Float64frombits(binary.LittleEndian.Uint64(data[:]))), err
+	case binaryString:
+		data, err := readBinaryBytes(r)
+		return ModelString(data), err
+	case binaryRune:
+		v, err := binary.ReadVarint(r)
+		return ModelRune(v), err
+	case binaryBoolFalse, binaryBoolTrue:
+		return ModelBool(tag == binaryBoolTrue), nil
+	case binaryTime:
+		data, err := readBinaryBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		var t time.Time
+		if err := t.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return ModelTime(t), nil
+	case binaryDuration:
+		v, err := binary.ReadVarint(r)
+		return ModelDuration(v), err
+	case binarySlice:
+		return readBinarySlice(r, depth)
+	case binaryMap:
+		mm := ModelMap{}
+		err := readBinaryPairs(r, depth, func(k, v Model) error {
+			return putBinaryKey(mm, k, v)
+		})
+		return mm, err
+	case binaryLinkedMap:
+		lm := NewModelLinkedMap()
+		err := readBinaryPairs(r, depth, func(k, v Model) error {
+			lm.Put(k, v)
+			return nil
+		})
+		return lm, err
+	case binarySet:
+		slice, err := readBinarySlice(r, depth)
+		return NewModelSet(slice...), err
+	case binaryHashMap:
+		hm := NewHashMap()
+		err := readBinaryPairs(r, depth, func(k, v Model) error {
+			hm.Put(k, v)
+			return nil
+		})
+		return hm, err
+	case binaryHashSet:
+		slice, err := readBinarySlice(r, depth)
+		return NewHashSet(slice...), err
+	case binaryRegistered:
+		return readBinaryRegistered(r)
+	case binaryPair:
+		first, err := readBinary(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		second, err := readBinary(r, depth+1)
+		return NewModelPair(first, second), err
+	case binaryTuple:
+		slice, err := readBinarySlice(r, depth)
+		return NewModelTuple(slice...), err
+	default:
+		return nil, fmt.Errorf("model: invalid binary tag %d", tag)
+	}
+}
+
+func readBinarySlice(r binaryReader, depth int) (ModelSlice, error) {
+	n, err := readBinaryLen(r)
+	if err != nil {
+		return nil, err
+	}
+
+	slice := ModelSlice{}
+	for i := 0; i < n; i++ {
+		m, err := readBinary(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		slice = append(slice, m)
+	}
+	return slice, nil
+}
+
+func readBinaryPairs(r binaryReader, depth int, put func(k, v Model) error) error {
+	n, err := readBinaryLen(r)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		k, err := readBinary(r, depth+1)
+		if err != nil {
+			return err
+		}
+		v, err := readBinary(r, depth+1)
+		if err != nil {
+			return err
+		}
+		if err := put(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readBinaryRegistered(r binaryReader) (Model, error) {
+	name, err := readBinaryBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readBinaryBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ptr, result, err := registeredType(string(name))
+	if err != nil {
+		return nil, err
+	}
+	if unmarshaler, ok := ptr.(encoding.BinaryUnmarshaler); ok {
+		err = unmarshaler.UnmarshalBinary(data)
+	} else {
+		err = json.Unmarshal(data, ptr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result(), nil
+}
+
+// putBinaryKey adds the key and value to the map. A registered type can be
+// comparable while holding an unhashable value, such as a struct with an
+// interface field, so the panic of such a key is returned as an error.
+func putBinaryKey(mm ModelMap, k, v Model) (err error) {
+	if !IsMapKey(k) {
+		return fmt.Errorf("model: cannot use %T as a ModelMap key", k)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("model: cannot use %T as a ModelMap key: %v", k, r)
+		}
+	}()
+	mm[k] = v
+	return nil
+}
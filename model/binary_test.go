@@ -0,0 +1,194 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEncode_RoundTrip(t *testing.T) {
+	linked := NewModelLinkedMap()
+	linked.Put(ModelString("z"), ModelInt(1))
+	linked.Put(ModelSlice{ModelInt(1)}, nil)
+
+	hm := NewHashMap()
+	hm.Put(ModelMap{ModelString("k"): ModelInt(1)}, ModelString("v"))
+
+	type test struct {
+		name  string
+		model Model
+	}
+
+	table := []test{
+		{name: "Nil should round trip.", model: nil},
+		{name: "Negative ints should round trip.", model: ModelInt(-42)},
+		{name: "Large int64s should round trip.", model: ModelInt64(math.MinInt64)},
+		{name: "Large uint64s should round trip.", model: ModelUint64(math.MaxUint64)},
+		{name: "Bytes should round trip.", model: ModelByte(255)},
+		{name: "Floats should round trip.", model: ModelFloat(1.25)},
+		{name: "Float64s should round trip.", model: ModelFloat64(-3.5e100)},
+		{name: "Strings should round trip.", model: ModelString("héllo")},
+		{name: "Runes should round trip.", model: ModelRune('界')},
+		{name: "Bools should round trip.", model: ModelBool(true)},
+		{name: "Times should round trip.", model: ModelTime(time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC))},
+		{name: "Durations should round trip.", model: ModelDuration(-time.Minute)},
+		{
+			name: "Nested maps and slices should round trip.",
+			model: ModelMap{
+				ModelString("a"): ModelSlice{ModelInt(1), nil, ModelMap{ModelInt(2): ModelBool(false)}},
+				ModelInt(3):      ModelSlice{},
+			},
+		},
+		{name: "Linked maps should round trip in order.", model: linked},
+		{name: "Sets should round trip.", model: NewModelSet(ModelString("b"), ModelString("a"))},
+		{name: "Hash maps should round trip.", model: hm},
+		{name: "Hash sets should round trip.", model: NewHashSet(ModelSlice{ModelInt(1)})},
+		{name: "Pairs and tuples should round trip.", model: ModelSlice{NewModelPair(ModelInt(1), nil), NewModelTuple(ModelString("a"), ModelSlice{})}},
+		{name: "Registered models should round trip.", model: ModelSlice{point{X: 1, Y: -1}, &counter{N: 2}}},
+	}
+
+	for _, te := range table {
+		var buf bytes.Buffer
+		if err := Encode(&buf, te.model); err != nil {
+			t.Errorf("%v Got error %v.", te.name, err)
+			continue
+		}
+
+		result, err := Decode(&buf)
+		if err != nil {
+			t.Errorf("%v Got error %v.", te.name, err)
+			continue
+		}
+		if !ModelsEqual(te.model, result) {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.model, result)
+		}
+	}
+}
+
+func TestEncode_Unregistered(t *testing.T) {
+	if err := Encode(io.Discard, ModelSlice{Wrap(1)}); err == nil {
+		t.Error("Encoding a model whose type is not registered should return an error.")
+	}
+}
+
+func TestEncode_SetKey(t *testing.T) {
+	m := ModelMap{NewModelPair(NewModelSet(ModelInt(1)), nil): ModelInt(1)}
+	if err := Encode(io.Discard, m); err == nil {
+		t.Error("Encoding a ModelMap keyed by a set should return an error.")
+	}
+}
+
+func TestDecode_Sequence(t *testing.T) {
+	var buf bytes.Buffer
+	for i := 0; i < 3; i++ {
+		if err := Encode(&buf, ModelInt(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := bufio.NewReader(&buf)
+	for i := 0; i < 3; i++ {
+		m, err := Decode(r)
+		if err != nil || m != ModelInt(i) {
+			t.Errorf("Expected model %v but got %v (%v).", i, m, err)
+		}
+	}
+
+	if _, err := Decode(r); err != io.EOF {
+		t.Errorf("Expected io.EOF once every model has been read but got %v.", err)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	type test struct {
+		name  string
+		input []byte
+		want  error
+	}
+
+	table := []test{
+		{name: "A model that is cut short should return io.ErrUnexpectedEOF.", input: []byte{binarySlice, 2, binaryInt}, want: io.ErrUnexpectedEOF},
+		{name: "A string that is cut short should return io.ErrUnexpectedEOF.", input: []byte{binaryString, 5, 'a'}, want: io.ErrUnexpectedEOF},
+		{name: "Models nested too deeply should return ErrBinaryDepth.", input: bytes.Repeat([]byte{binarySlice, 1}, maxBinaryDepth+2), want: ErrBinaryDepth},
+		{name: "An unknown tag should return an error.", input: []byte{255}},
+		{name: "A slice used as a ModelMap key should return an error.", input: []byte{binaryMap, 1, binarySlice, 0, binaryNil}},
+		{name: "A pair holding a slice used as a ModelMap key should return an error.", input: []byte{binaryMap, 1, binaryPair, binarySlice, 0, binaryNil, binaryNil}},
+		{name: "A tuple holding a slice used as a ModelMap key should return an error.", input: []byte{binaryMap, 1, binaryTuple, 1, binarySlice, 0, binaryNil}},
+		{name: "A set used as a ModelMap key should return an error.", input: []byte{binaryMap, 1, binarySet, 0, binaryNil}},
+		{name: "A pair holding a HashMap used as a ModelMap key should return an error.", input: []byte{binaryMap, 1, binaryPair, binaryHashMap, 0, binaryNil, binaryNil}},
+	}
+
+	for _, te := range table {
+		_, err := Decode(bytes.NewReader(te.input))
+		if err == nil || (te.want != nil && !errors.Is(err, te.want)) {
+			t.Errorf("%v Got error %v.", te.name, err)
+		}
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, m := range []Model{
+		ModelMap{ModelString("a"): ModelSlice{ModelInt(1), ModelFloat64(2.5), nil}},
+		NewModelSet(ModelBool(true), ModelRune('x')),
+		ModelSlice{point{X: 1}},
+		ModelMap{NewModelPair(ModelInt(1), ModelString("a")): NewModelTuple(ModelSlice{}, nil)},
+		ModelMap{NewModelTuple(ModelInt(1), ModelBool(false)): NewModelPair(ModelSlice{}, nil)},
+	} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, m); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := Decode(bytes.NewReader(data))
+		if err != nil || !ModelsEqual(m, m) {
+			// Models holding NaN are never equal, so they cannot be checked.
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := Encode(&buf, m); err != nil {
+			t.Fatalf("Decoded model %v could not be encoded: %v", m, err)
+		}
+		result, err := Decode(&buf)
+		if err != nil {
+			t.Fatalf("Encoded model %v could not be decoded: %v", m, err)
+		}
+		if !ModelsEqual(m, result) {
+			t.Fatalf("Expected %v to round trip but got %v", m, result)
+		}
+	})
+}
+
+func FuzzEncode(f *testing.F) {
+	f.Add(int64(1), "a", 1.5, true)
+	f.Add(int64(-1), "", math.Inf(1), false)
+
+	f.Fuzz(func(t *testing.T, i int64, s string, fl float64, b bool) {
+		original := ModelMap{
+			ModelString(s): ModelSlice{ModelInt64(i), ModelFloat64(fl), ModelBool(b)},
+			ModelInt64(i):  NewModelSet(ModelString(s), ModelInt(i)),
+		}
+		if math.IsNaN(fl) {
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := Encode(&buf, original); err != nil {
+			t.Fatal(err)
+		}
+		result, err := Decode(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d, ok := DiffFirst(original, result); ok {
+			t.Fatalf("Model did not round trip, %v", d)
+		}
+	})
+}
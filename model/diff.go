@@ -0,0 +1,210 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiffKind describes how a value differs between two models.
+type DiffKind int
+
+const (
+	// DiffChanged means the path is in both models but holds different values.
+	DiffChanged DiffKind = iota
+	// DiffAdded means the path is only in the second model.
+	DiffAdded
+	// DiffRemoved means the path is only in the first model.
+	DiffRemoved
+)
+
+// Difference is a single difference found by Diff. The Path is empty when
+// the models themselves differ, and otherwise locates the value using the
+// names of map keys and the indexes of slices, such as "users[0].name".
+type Difference struct {
+	Path  string
+	Kind  DiffKind
+	Left  Model
+	Right Model
+}
+
+// String returns the difference in a form that is suitable for test output.
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "<root>"
+	}
+
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("%s: added %v", path, d.Right)
+	case DiffRemoved:
+		return fmt.Sprintf("%s: removed %v", path, d.Left)
+	default:
+		return fmt.Sprintf("%s: %v != %v", path, d.Left, d.Right)
+	}
+}
+
+// Diff compares the two models and returns every difference between them.
+// ModelSlice, ModelMap and ModelLinkedMap values are compared deeply, while
+// every other model is compared using ModelsEqual. Equal models have no
+// differences.
+//
+// The differences of a map are ordered by their path, so the result is the
+// same each time the same models are compared.
+func Diff(a, b Model) []Difference {
+	d := differ{max: -1}
+	d.diff("", a, b)
+	return d.diffs
+}
+
+// DiffFirst compares the two models and returns the first difference
+// between them, as it would be ordered by Diff. The bool is 'false' if the
+// models are equal.
+func DiffFirst(a, b Model) (Difference, bool) {
+	d := differ{max: 1}
+	d.diff("", a, b)
+	if len(d.diffs) == 0 {
+		return Difference{}, false
+	}
+	return d.diffs[0], true
+}
+
+// differ collects differences until it holds max of them. A negative max
+// collects every difference.
+type differ struct {
+	diffs []Difference
+	max   int
+}
+
+func (d *differ) done() bool {
+	return d.max >= 0 && len(d.diffs) >= d.max
+}
+
+func (d *differ) add(diff Difference) {
+	if !d.done() {
+		d.diffs = append(d.diffs, diff)
+	}
+}
+
+func (d *differ) diff(path string, a, b Model) {
+	if d.done() {
+		return
+	}
+
+	switch left := a.(type) {
+	case ModelSlice:
+		if right, ok := b.(ModelSlice); ok {
+			d.diffSlice(path, left, right)
+			return
+		}
+	case ModelMap:
+		if right, ok := b.(ModelMap); ok {
+			d.diffMap(path, left, right)
+			return
+		}
+	case *ModelLinkedMap:
+		if right, ok := b.(*ModelLinkedMap); ok && left != nil && right != nil {
+			d.diffLinkedMap(path, left, right)
+			return
+		}
+	}
+
+	if !ModelsEqual(a, b) {
+		d.add(Difference{Path: path, Kind: DiffChanged, Left: a, Right: b})
+	}
+}
+
+func (d *differ) diffSlice(path string, a, b ModelSlice) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(b):
+			d.add(Difference{Path: p, Kind: DiffRemoved, Left: a[i]})
+		case i >= len(a):
+			d.add(Difference{Path: p, Kind: DiffAdded, Right: b[i]})
+		default:
+			d.diff(p, a[i], b[i])
+		}
+	}
+}
+
+func (d *differ) diffMap(path string, a, b ModelMap) {
+	keys := make(ModelSlice, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keyPath("", keys[i]) < keyPath("", keys[j])
+	})
+
+	for _, k := range keys {
+		v1, ok1 := a[k]
+		v2, ok2 := b[k]
+		d.diffEntry(keyPath(path, k), v1, ok1, v2, ok2)
+	}
+}
+
+// diffLinkedMap compares the values of each key like a ModelMap. When every
+// value is equal but the keys are in a different order, the maps themselves
+// are reported as changed.
+func (d *differ) diffLinkedMap(path string, a, b *ModelLinkedMap) {
+	before := len(d.diffs)
+	keys := a.Keys()
+	for _, k := range b.Keys() {
+		if _, ok := a.Get(k); !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	for _, k := range keys {
+		v1, ok1 := a.Get(k)
+		v2, ok2 := b.Get(k)
+		d.diffEntry(keyPath(path, k), v1, ok1, v2, ok2)
+	}
+
+	if len(d.diffs) == before && !a.Equals(b) {
+		d.add(Difference{Path: path, Kind: DiffChanged, Left: a, Right: b})
+	}
+}
+
+func (d *differ) diffEntry(path string, v1 Model, ok1 bool, v2 Model, ok2 bool) {
+	switch {
+	case !ok2:
+		d.add(Difference{Path: path, Kind: DiffRemoved, Left: v1})
+	case !ok1:
+		d.add(Difference{Path: path, Kind: DiffAdded, Right: v2})
+	default:
+		d.diff(path, v1, v2)
+	}
+}
+
+// keyPath appends the map key to the path. A ModelString key that is a
+// plain name is joined with a dot, and every other key is placed within
+// brackets.
+func keyPath(path string, k Model) string {
+	if s, ok := k.(ModelString); ok {
+		if isPathName(string(s)) {
+			if path == "" {
+				return string(s)
+			}
+			return path + "." + string(s)
+		}
+		return fmt.Sprintf("%s[%q]", path, string(s))
+	}
+	return fmt.Sprintf("%s[%v]", path, k)
+}
+
+// isPathName returns 'true' if the name can be written after a dot within
+// a path, without needing to be quoted.
+func isPathName(name string) bool {
+	if name == "" {
+		return false
+	}
+	return !strings.ContainsAny(name, ".[]\"' \t\n")
+}
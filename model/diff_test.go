@@ -0,0 +1,120 @@
+package model
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	type test struct {
+		name  string
+		a, b  Model
+		paths []string
+	}
+
+	table := []test{
+		{
+			name:  "Equal nested models should have no differences.",
+			a:     ModelMap{ModelString("tags"): ModelSlice{ModelString("a"), nil}},
+			b:     ModelMap{ModelString("tags"): ModelSlice{ModelString("a"), nil}},
+			paths: nil,
+		},
+		{
+			name: "A changed nested value should be reported with its full path.",
+			a: ModelMap{ModelString("users"): ModelSlice{
+				ModelMap{ModelString("name"): ModelString("Alex")},
+			}},
+			b: ModelMap{ModelString("users"): ModelSlice{
+				ModelMap{ModelString("name"): ModelString("Sam")},
+			}},
+			paths: []string{"users[0].name"},
+		},
+		{
+			name:  "Keys that are only in one map should be reported in path order.",
+			a:     ModelMap{ModelString("b"): ModelInt(1), ModelString("c"): ModelInt(1)},
+			b:     ModelMap{ModelString("a"): ModelInt(1), ModelString("c"): ModelInt(1)},
+			paths: []string{"a", "b"},
+		},
+		{
+			name:  "Extra slice elements should be reported by index.",
+			a:     ModelSlice{ModelInt(1)},
+			b:     ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)},
+			paths: []string{"[1]", "[2]"},
+		},
+		{
+			name:  "Models of different types should be reported at the root.",
+			a:     ModelSlice{},
+			b:     ModelMap{},
+			paths: []string{""},
+		},
+		{
+			name:  "Keys that are not plain names should be placed in brackets.",
+			a:     ModelMap{ModelString("a b"): ModelInt(1), ModelInt(2): ModelInt(1)},
+			b:     ModelMap{ModelString("a b"): ModelInt(2), ModelInt(2): ModelInt(2)},
+			paths: []string{"[\"a b\"]", "[2]"},
+		},
+	}
+
+	for _, te := range table {
+		diffs := Diff(te.a, te.b)
+		if len(diffs) != len(te.paths) {
+			t.Errorf("%v Expected %v differences but got %v.", te.name, len(te.paths), diffs)
+			continue
+		}
+		for i, d := range diffs {
+			if d.Path != te.paths[i] {
+				t.Errorf("%v Expected path %q but got %q.", te.name, te.paths[i], d.Path)
+			}
+		}
+	}
+}
+
+func TestDiff_Kind(t *testing.T) {
+	diffs := Diff(
+		ModelMap{ModelString("a"): ModelInt(1), ModelString("b"): nil},
+		ModelMap{ModelString("b"): ModelInt(2), ModelString("c"): ModelInt(3)},
+	)
+
+	want := []Difference{
+		{Path: "a", Kind: DiffRemoved, Left: ModelInt(1)},
+		{Path: "b", Kind: DiffChanged, Left: nil, Right: ModelInt(2)},
+		{Path: "c", Kind: DiffAdded, Right: ModelInt(3)},
+	}
+
+	if len(diffs) != len(want) {
+		t.Fatalf("Expected %v differences but got %v.", len(want), diffs)
+	}
+	for i, d := range diffs {
+		if d != want[i] {
+			t.Errorf("Expected %v but got %v.", want[i], d)
+		}
+	}
+}
+
+func TestDiff_LinkedMapOrder(t *testing.T) {
+	a, b := NewModelLinkedMap(), NewModelLinkedMap()
+	a.Put(ModelString("x"), ModelInt(1))
+	a.Put(ModelString("y"), ModelInt(2))
+	b.Put(ModelString("y"), ModelInt(2))
+	b.Put(ModelString("x"), ModelInt(1))
+
+	diffs := Diff(a, b)
+	if len(diffs) != 1 || diffs[0].Path != "" {
+		t.Errorf("Expected a single difference at the root for a different key order but got %v.", diffs)
+	}
+}
+
+func TestDiffFirst(t *testing.T) {
+	d, ok := DiffFirst(
+		ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)},
+		ModelSlice{ModelInt(1), ModelInt(5), ModelInt(6)},
+	)
+	if !ok || d.Path != "[1]" {
+		t.Errorf("Expected the first difference to be at [1] but got %v.", d)
+	}
+
+	if d.String() != "[1]: 2 != 5" {
+		t.Errorf("Expected the difference to be formatted as '[1]: 2 != 5' but got %q.", d.String())
+	}
+
+	if _, ok := DiffFirst(ModelInt(1), ModelInt(1)); ok {
+		t.Error("Equal models should not have a first difference.")
+	}
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+)
+
+// Every built-in model is registered with encoding/gob, so that they can be
+// sent within a field of type Model. Custom models must still be registered
+// with gob.Register.
+func init() {
+	for _, m := range []Model{
+		ModelInt(0), ModelInt64(0), ModelUint64(0), ModelByte(0),
+		ModelFloat(0), ModelFloat64(0), ModelString(""), ModelRune(0),
+		ModelBool(false), ModelTime{}, ModelDuration(0),
+		ModelSlice{}, ModelMap{}, &ModelLinkedMap{}, &ModelSet{},
+		&HashMap{}, &HashSet{}, ModelPair{}, ModelTuple{},
+	} {
+		gob.Register(m)
+	}
+}
+
+// GobEncode encodes the time the same way as time.Time.
+func (mt ModelTime) GobEncode() ([]byte, error) {
+	return time.Time(mt).GobEncode()
+}
+
+// GobDecode decodes a time that was encoded the same way as time.Time.
+func (mt *ModelTime) GobDecode(data []byte) error {
+	return (*time.Time)(mt).GobDecode(data)
+}
+
+// gobEncode encodes the model using Encode, for the models whose fields are
+// not exported.
+func gobEncode(m Model) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gobDecodeInto decodes the data using Decode and copies the result into dst.
+func gobDecodeInto[T any, P interface {
+	*T
+	Model
+}](dst P, data []byte) error {
+	m, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	switch decoded := m.(type) {
+	case P:
+		*dst = *decoded
+	case T:
+		*dst = decoded
+	default:
+		return fmt.Errorf("model: cannot decode %T into a %T", m, dst)
+	}
+	return nil
+}
+
+// GobEncode encodes lm using Encode, keeping the order of the keys.
+func (lm *ModelLinkedMap) GobEncode() ([]byte, error) {
+	return gobEncode(lm)
+}
+
+// GobDecode decodes a ModelLinkedMap that was encoded by GobEncode.
+func (lm *ModelLinkedMap) GobDecode(data []byte) error {
+	return gobDecodeInto(lm, data)
+}
+
+// GobEncode encodes ms using Encode, keeping the order of the models.
+func (ms *ModelSet) GobEncode() ([]byte, error) {
+	return gobEncode(ms)
+}
+
+// GobDecode decodes a ModelSet that was encoded by GobEncode.
+func (ms *ModelSet) GobDecode(data []byte) error {
+	return gobDecodeInto(ms, data)
+}
+
+// GobEncode encodes hm using Encode.
+func (hm *HashMap) GobEncode() ([]byte, error) {
+	return gobEncode(hm)
+}
+
+// GobDecode decodes a HashMap that was encoded by GobEncode.
+func (hm *HashMap) GobDecode(data []byte) error {
+	return gobDecodeInto(hm, data)
+}
+
+// GobEncode encodes hs using Encode.
+func (hs *HashSet) GobEncode() ([]byte, error) {
+	return gobEncode(hs)
+}
+
+// GobDecode decodes a HashSet that was encoded by GobEncode.
+func (hs *HashSet) GobDecode(data []byte) error {
+	return gobDecodeInto(hs, data)
+}
+
+// GobEncode encodes mt using Encode.
+func (mt ModelTuple) GobEncode() ([]byte, error) {
+	return gobEncode(mt)
+}
+
+// GobDecode decodes a ModelTuple that was encoded by GobEncode.
+func (mt *ModelTuple) GobDecode(data []byte) error {
+	return gobDecodeInto(mt, data)
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+func TestGob_RoundTrip(t *testing.T) {
+	type result struct {
+		Name   string
+		Values Model
+	}
+
+	linked := NewModelLinkedMap()
+	linked.Put(ModelString("b"), ModelTime(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)))
+	linked.Put(ModelString("a"), NewModelSet(ModelInt(1), ModelInt(2)))
+
+	original := result{
+		Name: "totals",
+		Values: ModelMap{
+			ModelString("slice"):  ModelSlice{ModelInt(1), ModelFloat64(2.5), ModelDuration(time.Second)},
+			ModelInt(2):           linked,
+			ModelString("hashed"): NewHashSet(ModelSlice{ModelString("x")}),
+			ModelString("pair"):   NewModelPair(ModelInt(1), NewModelTuple(ModelString("y"), nil)),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(original); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded result
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if d, ok := DiffFirst(original.Values, decoded.Values); ok {
+		t.Errorf("Models did not round trip through gob, %v.", d)
+	}
+}
@@ -0,0 +1,130 @@
+package model
+
+import "math"
+
+// Hasher is implemented by models that can produce a 64 bit hash of
+// themselves. Models that are equal must always produce the same hash,
+// while models that are not equal should rarely do so.
+//
+// Every built-in model implements Hasher, which allows them to be used as
+// keys within a HashMap or HashSet, including the composite models such
+// as ModelSlice and ModelMap.
+type Hasher interface {
+	Model
+	Hash() uint64
+}
+
+// HashModel returns the hash of m. Models that implement Hasher are hashed
+// using their Hash method. Every other model shares the same hash, since
+// nothing but Equals is known about them, so they are still found by Equals
+// within a HashMap or HashSet, though only by comparing each of them.
+func HashModel(m Model) uint64 {
+	switch v := m.(type) {
+	case nil:
+		return uint64(newFnv64(tagNil))
+	case Hasher:
+		return v.Hash()
+	default:
+		return uint64(newFnv64(tagOther))
+	}
+}
+
+// HashBuilder builds up a hash one value at a time, in an order-dependent
+// way. It is used by code generated with fungo-gen to hash struct fields.
+//
+//	h := model.NewHashBuilder("Employee")
+//	h = h.Add(model.ModelString(e.Name).Hash())
+//	return h.Sum()
+type HashBuilder uint64
+
+// NewHashBuilder returns a HashBuilder that starts from the hash of the name,
+// so that values of different types are unlikely to produce the same hash.
+func NewHashBuilder(name string) HashBuilder {
+	return HashBuilder(newFnv64(tagOther).addString(name))
+}
+
+// Add returns the builder with the hash h added to it.
+func (hb HashBuilder) Add(h uint64) HashBuilder {
+	return HashBuilder(fnv64(hb).addUint64(h))
+}
+
+// Sum returns the hash that has been built.
+func (hb HashBuilder) Sum() uint64 {
+	return uint64(hb)
+}
+
+// The tags are hashed before the value of a model, so that models of
+// different types are unlikely to produce the same hash.
+const (
+	tagNil byte = iota
+	tagOther
+	tagInt
+	tagInt64
+	tagUint64
+	tagByte
+	tagFloat
+	tagFloat64
+	tagString
+	tagRune
+	tagBool
+	tagTime
+	tagDuration
+	tagSlice
+	tagMap
+	tagMapEntry
+	tagLinkedMap
+	tagSet
+	tagValue
+	tagPair
+	tagTuple
+)
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// fnv64 is a FNV-1a hash that is built up one value at a time.
+type fnv64 uint64
+
+func newFnv64(tag byte) fnv64 {
+	return fnv64(fnvOffset64).addByte(tag)
+}
+
+func (h fnv64) addByte(b byte) fnv64 {
+	h ^= fnv64(b)
+	h *= fnvPrime64
+	return h
+}
+
+func (h fnv64) addUint64(v uint64) fnv64 {
+	for i := 0; i < 8; i++ {
+		h = h.addByte(byte(v >> (8 * i)))
+	}
+	return h
+}
+
+func (h fnv64) addString(s string) fnv64 {
+	for i := 0; i < len(s); i++ {
+		h = h.addByte(s[i])
+	}
+	return h
+}
+
+// addFloat adds the float so that 0 and -0, which are equal, hash the same.
+func (h fnv64) addFloat(f float64) fnv64 {
+	if f == 0 {
+		f = 0
+	}
+	return h.addUint64(math.Float64bits(f))
+}
+
+// hashEntries combines the hashes of key and value pairs without depending
+// on their order, which is needed for unordered maps and sets.
+func hashEntries(tag byte, size int, forEach func(func(k, v Model))) uint64 {
+	var sum uint64
+	forEach(func(k, v Model) {
+		sum += uint64(newFnv64(tagMapEntry).addUint64(HashModel(k)).addUint64(HashModel(v)))
+	})
+	return uint64(newFnv64(tag).addUint64(uint64(size)).addUint64(sum))
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHashModel(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]Model
+	}
+
+	table := []test{
+		{
+			name:   "Equal ints should produce the same hash.",
+			models: [2]Model{ModelInt(42), ModelInt(42)},
+		},
+		{
+			name:   "Zero and negative zero floats are equal and should produce the same hash.",
+			models: [2]Model{ModelFloat64(0), ModelFloat64(math.Copysign(0, -1))},
+		},
+		{
+			name: "Equal times in different locations should produce the same hash.",
+			models: [2]Model{
+				ModelTime(time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)),
+				ModelTime(time.Date(2022, 1, 1, 7, 0, 0, 0, time.FixedZone("EST", -5*60*60))),
+			},
+		},
+		{
+			name:   "Equal slices should produce the same hash.",
+			models: [2]Model{ModelSlice{ModelInt(1), ModelString("a")}, ModelSlice{ModelInt(1), ModelString("a")}},
+		},
+		{
+			name: "Equal maps should produce the same hash.",
+			models: [2]Model{
+				ModelMap{ModelString("a"): ModelInt(1), ModelString("b"): ModelInt(2)},
+				ModelMap{ModelString("b"): ModelInt(2), ModelString("a"): ModelInt(1)},
+			},
+		},
+		{
+			name:   "Sets with a different insertion order should produce the same hash.",
+			models: [2]Model{NewModelSet(ModelInt(1), ModelInt(2)), NewModelSet(ModelInt(2), ModelInt(1))},
+		},
+		{
+			name:   "Nil models should produce the same hash.",
+			models: [2]Model{nil, nil},
+		},
+	}
+
+	for _, te := range table {
+		if HashModel(te.models[0]) != HashModel(te.models[1]) {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestHashModel_Different(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]Model
+	}
+
+	table := []test{
+		{
+			name:   "Different ints should produce different hashes.",
+			models: [2]Model{ModelInt(1), ModelInt(2)},
+		},
+		{
+			name:   "The same value of different types should produce different hashes.",
+			models: [2]Model{ModelInt(1), ModelInt64(1)},
+		},
+		{
+			name:   "Slices in a different order should produce different hashes.",
+			models: [2]Model{ModelSlice{ModelInt(1), ModelInt(2)}, ModelSlice{ModelInt(2), ModelInt(1)}},
+		},
+		{
+			name:   "A slice and a set of the same models should produce different hashes.",
+			models: [2]Model{ModelSlice{ModelInt(1)}, NewModelSet(ModelInt(1))},
+		},
+	}
+
+	for _, te := range table {
+		if HashModel(te.models[0]) == HashModel(te.models[1]) {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestHashBuilder(t *testing.T) {
+	h1 := NewHashBuilder("pair").Add(ModelInt(1).Hash()).Add(ModelInt(2).Hash())
+	h2 := NewHashBuilder("pair").Add(ModelInt(1).Hash()).Add(ModelInt(2).Hash())
+	h3 := NewHashBuilder("pair").Add(ModelInt(2).Hash()).Add(ModelInt(1).Hash())
+
+	if h1.Sum() != h2.Sum() {
+		t.Error("Adding the same hashes in the same order should produce the same hash.")
+	}
+
+	if h1.Sum() == h3.Sum() {
+		t.Error("Adding the same hashes in a different order should produce different hashes.")
+	}
+}
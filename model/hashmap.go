@@ -0,0 +1,133 @@
+package model
+
+// hashEntry is a single key and value pair within a HashMap.
+type hashEntry struct {
+	key   Model
+	value Model
+}
+
+// HashMap is a Model for a map of Model keys and values that uses the
+// hash of each key, along with Equals, to find its value. Unlike ModelMap,
+// any Model can be used as a key, including a ModelSlice or ModelMap.
+//
+// Keys are hashed using HashModel, so a key that is changed after being
+// placed in the map may no longer be found. The order in which keys are
+// visited is not specified.
+type HashMap struct {
+	buckets map[uint64][]hashEntry
+	size    int
+}
+
+// NewHashMap creates and returns an empty HashMap.
+func NewHashMap() *HashMap {
+	return &HashMap{buckets: map[uint64][]hashEntry{}}
+}
+
+// Put associates the value v with the key k, replacing any value that was
+// already associated with an equal key.
+func (hm *HashMap) Put(k, v Model) {
+	h := HashModel(k)
+	bucket := hm.buckets[h]
+	for i, e := range bucket {
+		if ModelsEqual(e.key, k) {
+			bucket[i].value = v
+			return
+		}
+	}
+	hm.buckets[h] = append(bucket, hashEntry{key: k, value: v})
+	hm.size++
+}
+
+// Get returns the value associated with the key k and whether or not
+// the key was present in the map.
+func (hm *HashMap) Get(k Model) (Model, bool) {
+	for _, e := range hm.buckets[HashModel(k)] {
+		if ModelsEqual(e.key, k) {
+			return e.value, true
+		}
+	}
+	return nil, false
+}
+
+// Contains checks and returns 'true' if the key k is within the map.
+func (hm *HashMap) Contains(k Model) bool {
+	_, ok := hm.Get(k)
+	return ok
+}
+
+// Delete removes the key k from the map and returns 'true' if the key
+// was present.
+func (hm *HashMap) Delete(k Model) bool {
+	h := HashModel(k)
+	bucket := hm.buckets[h]
+	for i, e := range bucket {
+		if ModelsEqual(e.key, k) {
+			if len(bucket) == 1 {
+				delete(hm.buckets, h)
+			} else {
+				hm.buckets[h] = append(bucket[:i:i], bucket[i+1:]...)
+			}
+			hm.size--
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the total number of keys within the map.
+func (hm *HashMap) Len() int {
+	return hm.size
+}
+
+// Keys returns a ModelSlice of every key within the map.
+func (hm *HashMap) Keys() ModelSlice {
+	keys := make(ModelSlice, 0, hm.size)
+	hm.ForEach(func(k, _ Model) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// ForEach calls f on every key and value pair within the map.
+func (hm *HashMap) ForEach(f func(k, v Model)) {
+	for _, bucket := range hm.buckets {
+		for _, e := range bucket {
+			f(e.key, e.value)
+		}
+	}
+}
+
+// Equals checks and returns 'true' if m is a HashMap that contains equal
+// keys that are associated with equal values.
+func (hm *HashMap) Equals(m Model) bool {
+	other, ok := m.(*HashMap)
+	if !ok || other == nil || hm.Len() != other.Len() {
+		return false
+	}
+
+	for _, bucket := range hm.buckets {
+		for _, e := range bucket {
+			v, ok := other.Get(e.key)
+			if !ok || !ModelsEqual(e.value, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Hash returns the hash of hm, which does not depend on the order in which
+// the keys are visited.
+func (hm *HashMap) Hash() uint64 {
+	return hashEntries(tagMap, hm.size, hm.ForEach)
+}
+
+// Entries returns a ModelSlice that holds a ModelPair of every key and value
+// within the map. The order of the entries is not specified.
+func (hm *HashMap) Entries() ModelSlice {
+	entries := make(ModelSlice, 0, hm.size)
+	hm.ForEach(func(k, v Model) {
+		entries = append(entries, NewModelPair(k, v))
+	})
+	return entries
+}
@@ -0,0 +1,126 @@
+package model
+
+import "testing"
+
+func TestHashMap_Put(t *testing.T) {
+	hm := NewHashMap()
+	hm.Put(ModelSlice{ModelInt(1), ModelInt(2)}, ModelString("a"))
+	hm.Put(ModelMap{ModelString("k"): ModelInt(1)}, ModelString("b"))
+	hm.Put(ModelSlice{ModelInt(1), ModelInt(2)}, ModelString("c"))
+
+	if hm.Len() != 2 {
+		t.Errorf("Expected the map to contain 2 keys but it has %v.", hm.Len())
+	}
+
+	v, ok := hm.Get(ModelSlice{ModelInt(1), ModelInt(2)})
+	if !ok || v != ModelString("c") {
+		t.Errorf("Expected the replaced value 'c' but got %v.", v)
+	}
+
+	v, ok = hm.Get(ModelMap{ModelString("k"): ModelInt(1)})
+	if !ok || v != ModelString("b") {
+		t.Errorf("Expected a map key to find 'b' but got %v.", v)
+	}
+
+	if hm.Contains(ModelSlice{ModelInt(2), ModelInt(1)}) {
+		t.Error("A key that was never put should not be within the map.")
+	}
+}
+
+// collidingKey is a Model whose every value hashes the same, which forces
+// all of them into a single bucket.
+type collidingKey int
+
+func (c collidingKey) Equals(m Model) bool {
+	other, ok := m.(collidingKey)
+	return ok && c == other
+}
+
+func (c collidingKey) Hash() uint64 {
+	return 7
+}
+
+func TestHashMap_Collisions(t *testing.T) {
+	hm := NewHashMap()
+	for i := 0; i < 5; i++ {
+		hm.Put(collidingKey(i), ModelInt(i))
+	}
+
+	if !hm.Delete(collidingKey(2)) {
+		t.Error("Deleting a key within the map should return true.")
+	}
+
+	if hm.Delete(collidingKey(2)) {
+		t.Error("Deleting a key that is not in the map should return false.")
+	}
+
+	for i := 0; i < 5; i++ {
+		v, ok := hm.Get(collidingKey(i))
+		if i == 2 {
+			if ok {
+				t.Error("Deleted key should no longer be within the map.")
+			}
+			continue
+		}
+		if !ok || v != ModelInt(i) {
+			t.Errorf("Expected key %v to hold %v but got %v.", i, i, v)
+		}
+	}
+
+	if hm.Len() != 4 {
+		t.Errorf("Expected the map to contain 4 keys but it has %v.", hm.Len())
+	}
+}
+
+func TestHashMap_Equals(t *testing.T) {
+	newMap := func(pairs ...Model) *HashMap {
+		hm := NewHashMap()
+		for i := 0; i < len(pairs); i += 2 {
+			hm.Put(pairs[i], pairs[i+1])
+		}
+		return hm
+	}
+
+	type test struct {
+		name   string
+		models [2]*HashMap
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both maps contain the same keys and values, should return true.",
+			models: [2]*HashMap{
+				newMap(ModelSlice{ModelInt(1)}, ModelInt(1), ModelString("a"), ModelInt(2)),
+				newMap(ModelString("a"), ModelInt(2), ModelSlice{ModelInt(1)}, ModelInt(1)),
+			},
+			want: true,
+		},
+		{
+			name: "Both maps contain the same keys with different values, should return false.",
+			models: [2]*HashMap{
+				newMap(ModelString("a"), ModelInt(1)),
+				newMap(ModelString("a"), ModelInt(2)),
+			},
+			want: false,
+		},
+		{
+			name: "One map contains more keys than the other, should return false.",
+			models: [2]*HashMap{
+				newMap(ModelString("a"), ModelInt(1)),
+				newMap(ModelString("a"), ModelInt(1), ModelString("b"), ModelInt(1)),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+		if te.want && model1.Hash() != model2.Hash() {
+			t.Errorf("%v Equal maps should have the same hash.", te.name)
+		}
+	}
+}
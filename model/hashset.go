@@ -0,0 +1,79 @@
+package model
+
+// HashSet is a Model for a collection of unique Models that uses the hash
+// of each model, along with Equals, to find it. Any Model can be placed in
+// a HashSet, including a ModelSlice or ModelMap.
+//
+// The order in which models are visited is not specified. Use a ModelSet
+// when the order the models were added in should be kept.
+type HashSet struct {
+	items *HashMap
+}
+
+// NewHashSet creates and returns a HashSet containing all the given models.
+func NewHashSet(models ...Model) *HashSet {
+	set := &HashSet{items: NewHashMap()}
+	for _, m := range models {
+		set.Add(m)
+	}
+	return set
+}
+
+// Add places m into the set and returns 'true' if the model was not
+// already in the set.
+func (hs *HashSet) Add(m Model) bool {
+	if hs.items.Contains(m) {
+		return false
+	}
+	hs.items.Put(m, nil)
+	return true
+}
+
+// Remove takes m out of the set and returns 'true' if the model was
+// present in the set.
+func (hs *HashSet) Remove(m Model) bool {
+	return hs.items.Delete(m)
+}
+
+// Contains checks and returns 'true' if m is within the set.
+func (hs *HashSet) Contains(m Model) bool {
+	return hs.items.Contains(m)
+}
+
+// Len returns the total number of models within the set.
+func (hs *HashSet) Len() int {
+	return hs.items.Len()
+}
+
+// Slice returns a ModelSlice of every model within the set.
+func (hs *HashSet) Slice() ModelSlice {
+	return hs.items.Keys()
+}
+
+// ForEach calls f on every model within the set.
+func (hs *HashSet) ForEach(f func(m Model)) {
+	hs.items.ForEach(func(k, _ Model) {
+		f(k)
+	})
+}
+
+// Equals checks and returns 'true' if m is a HashSet that contains
+// exactly the same models as hs.
+func (hs *HashSet) Equals(m Model) bool {
+	other, ok := m.(*HashSet)
+	if !ok || other == nil || hs.Len() != other.Len() {
+		return false
+	}
+
+	equal := true
+	hs.ForEach(func(m Model) {
+		equal = equal && other.Contains(m)
+	})
+	return equal
+}
+
+// Hash returns the hash of hs, which does not depend on the order in
+// which the models are visited.
+func (hs *HashSet) Hash() uint64 {
+	return hashEntries(tagSet, hs.Len(), hs.items.ForEach)
+}
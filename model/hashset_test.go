@@ -0,0 +1,109 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashSet_Add(t *testing.T) {
+	set := NewHashSet(ModelSlice{ModelInt(1)}, ModelSlice{ModelInt(2)}, ModelSlice{ModelInt(1)})
+
+	if set.Len() != 2 {
+		t.Errorf("Expected the set to contain 2 models but it has %v.", set.Len())
+	}
+
+	if set.Add(ModelSlice{ModelInt(2)}) {
+		t.Error("Adding a model that is already in the set should return false.")
+	}
+
+	if !set.Remove(ModelSlice{ModelInt(2)}) || set.Contains(ModelSlice{ModelInt(2)}) {
+		t.Error("Removed model is still within the set.")
+	}
+}
+
+// caseInsensitive has a custom Equals but no Hash method.
+type caseInsensitive string
+
+func (c caseInsensitive) Equals(m Model) bool {
+	other, ok := m.(caseInsensitive)
+	return ok && strings.EqualFold(string(c), string(other))
+}
+
+// boxed has no Hash method and is equal to another boxed holding a pointer
+// to an equal int, even though the pointers format differently.
+type boxed struct {
+	value *int
+}
+
+func (b boxed) Equals(m Model) bool {
+	other, ok := m.(boxed)
+	return ok && *b.value == *other.value
+}
+
+func TestHashSet_AddWithoutHash(t *testing.T) {
+	one, otherOne := 1, 1
+
+	type test struct {
+		name   string
+		models []Model
+		want   int
+	}
+
+	table := []test{
+		{
+			name:   "Models that are equal by their custom Equals should only be added once.",
+			models: []Model{caseInsensitive("Bob"), caseInsensitive("bob"), caseInsensitive("Sam")},
+			want:   2,
+		},
+		{
+			name:   "Models with equal values behind different pointers should only be added once.",
+			models: []Model{boxed{&one}, boxed{&otherOne}},
+			want:   1,
+		},
+		{
+			name:   "Slices of models without Hash should be compared with Equals.",
+			models: []Model{ModelSlice{caseInsensitive("A")}, ModelSlice{caseInsensitive("a")}},
+			want:   1,
+		},
+	}
+
+	for _, te := range table {
+		if got := NewHashSet(te.models...).Len(); got != te.want {
+			t.Errorf("%v Expected %v models but got %v.", te.name, te.want, got)
+		}
+	}
+}
+
+func TestHashSet_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]*HashSet
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both sets contain the same models in a different order, should return true.",
+			models: [2]*HashSet{
+				NewHashSet(ModelInt(1), ModelMap{ModelString("a"): ModelInt(1)}),
+				NewHashSet(ModelMap{ModelString("a"): ModelInt(1)}, ModelInt(1)),
+			},
+			want: true,
+		},
+		{
+			name: "Both sets contain different models, should return false.",
+			models: [2]*HashSet{
+				NewHashSet(ModelInt(1), ModelInt(2)),
+				NewHashSet(ModelInt(1), ModelInt(3)),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
@@ -0,0 +1,493 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The keys of the object that a registered model is encoded as, such as
+// {"$type":"employee","value":{"name":"Alex"}}. A map key of "$type" is
+// written with an extra '$', as are keys that already look escaped, such as
+// "$$type", so that maps are never mistaken for a registered model.
+const (
+	jsonTypeKey  = "$type"
+	jsonValueKey = "value"
+)
+
+// The type names that pairs and tuples are tagged with. Names starting with
+// '$' cannot be registered, so they never clash with a registered type.
+const (
+	jsonPairType  = "$pair"
+	jsonTupleType = "$tuple"
+)
+
+// ToJSON returns the JSON encoding of m. ModelMap keys are written as
+// strings in sorted order, a ModelLinkedMap keeps the order of its keys and
+// sets are written as arrays. A model whose type was registered with
+// RegisterType is written as an object that holds its type name and value,
+// so that FromJSON can decode it back into the same type. Pairs and tuples
+// are written the same way, with an array of their models as the value.
+// An error is returned if two keys of a map are written as the same string.
+func ToJSON(m Model) ([]byte, error) {
+	return appendJSON(nil, m)
+}
+
+// FromJSON decodes a single JSON value into models. Objects are decoded
+// into a ModelMap with ModelString keys, arrays into a ModelSlice, strings
+// into a ModelString, booleans into a ModelBool and null into nil. Numbers
+// are decoded into a ModelInt when they are whole, and otherwise into a
+// ModelFloat64.
+//
+// An object written by ToJSON for a registered model, which holds only a
+// "$type" name and a "value", is decoded back into that model, and an error
+// is returned if its type is not registered. Any other object is a map, even
+// when its first key is "$type".
+func FromJSON(data []byte) (Model, error) {
+	return decodeJSON(data, false)
+}
+
+func decodeJSON(data []byte, ordered bool) (Model, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	m, err := decodeJSONValue(dec, ordered)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("model: unexpected data after the JSON value")
+	}
+	return m, nil
+}
+
+// decodeJSONValue reads the next value from the decoder. When ordered is
+// 'true', objects are decoded into a ModelLinkedMap instead of a ModelMap.
+func decodeJSONValue(dec *json.Decoder, ordered bool) (Model, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '[' {
+			return decodeJSONArray(dec, ordered)
+		}
+		return decodeJSONObject(dec, ordered)
+	case string:
+		return ModelString(value), nil
+	case bool:
+		return ModelBool(value), nil
+	case json.Number:
+		if i, err := strconv.Atoi(value.String()); err == nil {
+			return ModelInt(i), nil
+		}
+		f, err := value.Float64()
+		return ModelFloat64(f), err
+	default:
+		return nil, nil
+	}
+}
+
+func decodeJSONArray(dec *json.Decoder, ordered bool) (Model, error) {
+	slice := ModelSlice{}
+	for dec.More() {
+		m, err := decodeJSONValue(dec, ordered)
+		if err != nil {
+			return nil, err
+		}
+		slice = append(slice, m)
+	}
+	_, err := dec.Token()
+	return slice, err
+}
+
+func decodeJSONObject(dec *json.Decoder, ordered bool) (Model, error) {
+	var (
+		mm = ModelMap{}
+		lm = NewModelLinkedMap()
+	)
+	put := func(key string, m Model) {
+		if ordered {
+			lm.Put(ModelString(unescapeJSONKey(key)), m)
+		} else {
+			mm[ModelString(unescapeJSONKey(key))] = m
+		}
+	}
+
+	for first := true; dec.More(); first = false {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+
+		if first && key == jsonTypeKey {
+			m, registered, err := decodeJSONRegistered(dec, ordered, put)
+			if err != nil || registered {
+				return m, err
+			}
+			continue
+		}
+
+		m, err := decodeJSONValue(dec, ordered)
+		if err != nil {
+			return nil, err
+		}
+		put(key, m)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if ordered {
+		return lm, nil
+	}
+	return mm, nil
+}
+
+// decodeJSONRegistered reads the rest of an object whose first key is
+// "$type". When the object only holds a type name and a value, the
+// registered model is returned. Otherwise the keys that were read are put
+// into the map and the bool is 'false', so the rest of the object is read
+// as a map.
+func decodeJSONRegistered(dec *json.Decoder, ordered bool, put func(key string, m Model)) (Model, bool, error) {
+	var name, value json.RawMessage
+	if err := dec.Decode(&name); err != nil {
+		return nil, false, err
+	}
+
+	var key string
+	if dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		key = token.(string)
+		if err := dec.Decode(&value); err != nil {
+			return nil, false, err
+		}
+	}
+
+	var typeName string
+	if key == jsonValueKey && !dec.More() && json.Unmarshal(name, &typeName) == nil {
+		if _, err := dec.Token(); err != nil {
+			return nil, false, err
+		}
+		m, err := decodeJSONTyped(typeName, value, ordered)
+		return m, true, err
+	}
+
+	for _, field := range []struct {
+		key string
+		raw json.RawMessage
+	}{{jsonTypeKey, name}, {key, value}} {
+		if field.raw == nil {
+			continue
+		}
+		m, err := decodeJSON(field.raw, ordered)
+		if err != nil {
+			return nil, false, err
+		}
+		put(field.key, m)
+	}
+	return nil, false, nil
+}
+
+// decodeJSONTyped decodes the value of a pair, tuple or registered model.
+func decodeJSONTyped(name string, value json.RawMessage, ordered bool) (Model, error) {
+	if name == jsonPairType || name == jsonTupleType {
+		m, err := decodeJSON(value, ordered)
+		if err != nil {
+			return nil, err
+		}
+		slice, ok := m.(ModelSlice)
+		switch {
+		case ok && name == jsonTupleType:
+			return NewModelTuple(slice...), nil
+		case ok && len(slice) == 2:
+			return NewModelPair(slice[0], slice[1]), nil
+		}
+		return nil, fmt.Errorf("model: invalid %s %s", name, value)
+	}
+
+	ptr, result, err := registeredType(name)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	if err := dec.Decode(ptr); err != nil {
+		return nil, err
+	}
+	return result(), nil
+}
+
+// appendJSON appends the JSON encoding of the model to buf.
+func appendJSON(buf []byte, m Model) ([]byte, error) {
+	if m == nil {
+		return append(buf, "null"...), nil
+	}
+	name, ok := registeredName(m)
+	switch m.(type) {
+	case ModelPair:
+		name, ok = jsonPairType, true
+	case ModelTuple:
+		name, ok = jsonTupleType, true
+	}
+
+	if ok {
+		buf = append(buf, `{"`+jsonTypeKey+`":`...)
+		buf = strconv.AppendQuote(buf, name)
+		buf = append(buf, `,"`+jsonValueKey+`":`...)
+
+		var err error
+		if buf, err = appendJSONValue(buf, m); err != nil {
+			return nil, err
+		}
+		return append(buf, '}'), nil
+	}
+	return appendJSONValue(buf, m)
+}
+
+// appendJSONValue appends the JSON encoding of the model to buf, without
+// tagging it with its registered type name.
+func appendJSONValue(buf []byte, m Model) ([]byte, error) {
+	switch value := m.(type) {
+	case ModelSlice:
+		return appendJSONArray(buf, value)
+	case ModelMap:
+		fields := newJSONFields(len(value))
+		for k, v := range value {
+			if err := fields.add(k, v); err != nil {
+				return nil, err
+			}
+		}
+		sort.Strings(fields.names)
+		return appendJSONObject(buf, fields)
+	case *ModelLinkedMap:
+		fields := newJSONFields(value.Len())
+		var err error
+		value.ForEach(func(k, v Model) {
+			if err == nil {
+				err = fields.add(k, v)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONObject(buf, fields)
+	case *ModelSet:
+		return appendJSONArray(buf, value.Slice())
+	case ModelPair:
+		return appendJSONArray(buf, ModelSlice{value.First, value.Second})
+	case ModelTuple:
+		return appendJSONArray(buf, value.Slice())
+	case *HashSet:
+		return appendJSONArray(buf, value.Slice())
+	case Value:
+		return appendMarshal(buf, value.v)
+	default:
+		return appendMarshal(buf, value)
+	}
+}
+
+func appendMarshal(buf []byte, v any) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, encoded...), nil
+}
+
+func appendJSONArray(buf []byte, slice ModelSlice) ([]byte, error) {
+	buf = append(buf, '[')
+	for i, elem := range slice {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+
+		var err error
+		if buf, err = appendJSON(buf, elem); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+// jsonFields holds the fields of an object, in the order they are written.
+type jsonFields struct {
+	names  []string
+	keys   map[string]Model
+	values map[string]Model
+}
+
+func newJSONFields(size int) *jsonFields {
+	return &jsonFields{
+		names:  make([]string, 0, size),
+		keys:   make(map[string]Model, size),
+		values: make(map[string]Model, size),
+	}
+}
+
+// add adds the key and value as a field, and returns an error if another
+// key is written as the same name, such as ModelInt(1) and ModelString("1").
+func (f *jsonFields) add(k, v Model) error {
+	name := jsonKey(k)
+	if other, ok := f.keys[name]; ok {
+		return fmt.Errorf("model: map keys %v (%T) and %v (%T) are both written as the JSON name %q",
+			other, other, k, k, name)
+	}
+	f.names = append(f.names, name)
+	f.keys[name] = k
+	f.values[name] = v
+	return nil
+}
+
+func appendJSONObject(buf []byte, fields *jsonFields) ([]byte, error) {
+	buf = append(buf, '{')
+	for i, name := range fields.names {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		quoted, _ := json.Marshal(name)
+		buf = append(append(buf, quoted...), ':')
+
+		var err error
+		if buf, err = appendJSON(buf, fields.values[name]); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// jsonKey returns the map key as the string used for the name of a field.
+func jsonKey(k Model) string {
+	if s, ok := k.(ModelString); ok {
+		return escapeJSONKey(string(s))
+	}
+	return escapeJSONKey(fmt.Sprint(k))
+}
+
+// isJSONTypeKey returns 'true' if the key is "$type" with any number of
+// extra '$' before it.
+func isJSONTypeKey(key string) bool {
+	return strings.HasPrefix(key, "$") && strings.TrimLeft(key, "$") == jsonTypeKey[1:]
+}
+
+func escapeJSONKey(key string) string {
+	if isJSONTypeKey(key) {
+		return "$" + key
+	}
+	return key
+}
+
+func unescapeJSONKey(key string) string {
+	if isJSONTypeKey(key) && key != jsonTypeKey {
+		return key[1:]
+	}
+	return key
+}
+
+// MarshalJSON returns the JSON encoding of mm as an object.
+func (mm ModelMap) MarshalJSON() ([]byte, error) {
+	return appendJSONValue(nil, mm)
+}
+
+// UnmarshalJSON decodes a JSON object into mm, using FromJSON for each value.
+func (mm *ModelMap) UnmarshalJSON(data []byte) error {
+	m, err := FromJSON(data)
+	if err != nil {
+		return err
+	}
+	return assignJSON(mm, m)
+}
+
+// MarshalJSON returns the JSON encoding of ms as an array.
+func (ms ModelSlice) MarshalJSON() ([]byte, error) {
+	return appendJSONValue(nil, ms)
+}
+
+// UnmarshalJSON decodes a JSON array into ms, using FromJSON for each value.
+func (ms *ModelSlice) UnmarshalJSON(data []byte) error {
+	m, err := FromJSON(data)
+	if err != nil {
+		return err
+	}
+	return assignJSON(ms, m)
+}
+
+// MarshalJSON returns the JSON encoding of lm as an object that keeps the
+// order of the keys.
+func (lm *ModelLinkedMap) MarshalJSON() ([]byte, error) {
+	return appendJSONValue(nil, lm)
+}
+
+// UnmarshalJSON decodes a JSON object into lm, keeping the order of the keys.
+// Nested objects are decoded into a ModelLinkedMap as well.
+func (lm *ModelLinkedMap) UnmarshalJSON(data []byte) error {
+	m, err := decodeJSON(data, true)
+	if err != nil {
+		return err
+	}
+	decoded, ok := m.(*ModelLinkedMap)
+	if !ok {
+		return fmt.Errorf("model: cannot unmarshal %T into a ModelLinkedMap", m)
+	}
+	*lm = *decoded
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of ms as an array.
+func (ms *ModelSet) MarshalJSON() ([]byte, error) {
+	return appendJSONValue(nil, ms)
+}
+
+// UnmarshalJSON decodes a JSON array into ms, ignoring any duplicates.
+func (ms *ModelSet) UnmarshalJSON(data []byte) error {
+	var slice ModelSlice
+	if err := slice.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*ms = *NewModelSet(slice...)
+	return nil
+}
+
+// MarshalJSON returns the time formatted the same way as time.Time.
+func (mt ModelTime) MarshalJSON() ([]byte, error) {
+	return time.Time(mt).MarshalJSON()
+}
+
+// UnmarshalJSON decodes a time that is formatted the same way as time.Time.
+func (mt *ModelTime) UnmarshalJSON(data []byte) error {
+	return (*time.Time)(mt).UnmarshalJSON(data)
+}
+
+// MarshalJSON returns the JSON encoding of the wrapped value.
+func (mv Value) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mv.v)
+}
+
+// assignJSON stores the decoded model into dst, which must be a pointer to
+// the same type as the model.
+func assignJSON[T Model](dst *T, m Model) error {
+	if m == nil {
+		var zero T
+		*dst = zero
+		return nil
+	}
+	decoded, ok := m.(T)
+	if !ok {
+		return fmt.Errorf("model: cannot unmarshal %T into a %T", m, *dst)
+	}
+	*dst = decoded
+	return nil
+}
@@ -0,0 +1,321 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// point is a custom model used to test that registered types round trip.
+type point struct {
+	X, Y int
+}
+
+func (p point) Equals(m Model) bool {
+	other, ok := m.(point)
+	return ok && p == other
+}
+
+func init() {
+	RegisterType("model.point", point{})
+}
+
+func TestToJSON(t *testing.T) {
+	type test struct {
+		name  string
+		model Model
+		want  string
+	}
+
+	linked := NewModelLinkedMap()
+	linked.Put(ModelString("b"), ModelInt(1))
+	linked.Put(ModelString("a"), nil)
+
+	table := []test{
+		{
+			name:  "A map should be written with sorted keys and nested values.",
+			model: ModelMap{ModelString("b"): ModelSlice{ModelInt(1), ModelBool(true)}, ModelString("a"): ModelString("x")},
+			want:  `{"a":"x","b":[1,true]}`,
+		},
+		{
+			name:  "A map with numeric keys should write the keys as strings.",
+			model: ModelMap{ModelInt(2): ModelFloat64(1.5)},
+			want:  `{"2":1.5}`,
+		},
+		{
+			name:  "A linked map should keep the order of its keys.",
+			model: linked,
+			want:  `{"b":1,"a":null}`,
+		},
+		{
+			name:  "A set should be written as an array.",
+			model: NewModelSet(ModelString("a"), ModelString("b")),
+			want:  `["a","b"]`,
+		},
+		{
+			name:  "Pairs and tuples should be written with their type and models.",
+			model: ModelSlice{NewModelPair(ModelInt(1), ModelString("a")), NewModelTuple(ModelBool(true))},
+			want:  `[{"$type":"$pair","value":[1,"a"]},{"$type":"$tuple","value":[true]}]`,
+		},
+		{
+			name:  "A registered model should be written with its type name.",
+			model: ModelSlice{point{X: 1, Y: 2}},
+			want:  `[{"$type":"model.point","value":{"X":1,"Y":2}}]`,
+		},
+		{
+			name:  "A wrapped value should be written as the value itself.",
+			model: Wrap([]int{1, 2}),
+			want:  `[1,2]`,
+		},
+	}
+
+	for _, te := range table {
+		result, err := ToJSON(te.model)
+		if err != nil || string(result) != te.want {
+			t.Errorf("%v Expected %v but got %s (%v).", te.name, te.want, result, err)
+		}
+	}
+}
+
+func TestToJSON_KeyCollision(t *testing.T) {
+	type test struct {
+		name  string
+		model Model
+	}
+
+	linked := NewModelLinkedMap()
+	linked.Put(ModelString("1"), ModelInt(1))
+	linked.Put(ModelInt(1), ModelInt(2))
+
+	table := []test{
+		{
+			name:  "A ModelInt and ModelString key with the same text should return an error.",
+			model: ModelMap{ModelInt(1): ModelString("a"), ModelString("1"): ModelString("b")},
+		},
+		{
+			name:  "A pair key written the same as a string key should return an error.",
+			model: ModelMap{NewModelPair(ModelInt(1), ModelInt(2)): nil, ModelString("(1, 2)"): nil},
+		},
+		{
+			name:  "Colliding keys within a linked map should return an error.",
+			model: linked,
+		},
+	}
+
+	for _, te := range table {
+		if data, err := ToJSON(te.model); err == nil {
+			t.Errorf("%v Got %s.", te.name, data)
+		}
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	input := `{"user":{"name":"Alex","tags":["a",null]},"count":3,"score":1.5,"big":1e30,"at":{"$type":"model.point","value":{"X":1,"Y":2}}}`
+
+	result, err := FromJSON([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ModelMap{
+		ModelString("user"): ModelMap{
+			ModelString("name"): ModelString("Alex"),
+			ModelString("tags"): ModelSlice{ModelString("a"), nil},
+		},
+		ModelString("count"): ModelInt(3),
+		ModelString("score"): ModelFloat64(1.5),
+		ModelString("big"):   ModelFloat64(1e30),
+		ModelString("at"):    point{X: 1, Y: 2},
+	}
+
+	if d, ok := DiffFirst(want, result); ok {
+		t.Errorf("FromJSON did not decode the expected models, %v.", d)
+	}
+}
+
+func TestJSON_PairAndTuple(t *testing.T) {
+	m := ModelMap{
+		ModelString("pair"):  NewModelPair(ModelSlice{ModelInt(1)}, NewModelPair(nil, ModelString("b"))),
+		ModelString("tuple"): NewModelTuple(ModelInt(1), NewModelTuple(), ModelMap{ModelString("a"): ModelFloat64(1.5)}),
+	}
+
+	data, err := ToJSON(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("Got error %v decoding %s.", err, data)
+	}
+	if d, ok := DiffFirst(m, result); ok {
+		t.Errorf("Pairs and tuples should round trip through JSON, %v.", d)
+	}
+}
+
+func TestFromJSON_Errors(t *testing.T) {
+	type test struct {
+		name  string
+		input string
+	}
+
+	table := []test{
+		{name: "Malformed JSON should return an error.", input: `{"a":`},
+		{name: "Data after the value should return an error.", input: `1 2`},
+		{name: "An unregistered type should return an error.", input: `{"$type":"unknown","value":1}`},
+		{name: "A pair without two models should return an error.", input: `{"$type":"$pair","value":[1]}`},
+		{name: "A tuple that is not an array should return an error.", input: `{"$type":"$tuple","value":1}`},
+	}
+
+	for _, te := range table {
+		if _, err := FromJSON([]byte(te.input)); err == nil {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestJSON_TypeKey(t *testing.T) {
+	type test struct {
+		name  string
+		model Model
+	}
+
+	table := []test{
+		{
+			name:  "A map with a $type key that names a registered type should round trip.",
+			model: ModelMap{ModelString("$type"): ModelString("model.point"), ModelString("value"): ModelInt(1)},
+		},
+		{
+			name:  "A map with keys that look escaped should round trip.",
+			model: ModelMap{ModelString("$$type"): ModelInt(1), ModelString("$$$type"): ModelInt(2), ModelString("$$x"): ModelInt(3)},
+		},
+	}
+
+	for _, te := range table {
+		data, err := ToJSON(te.model)
+		if err != nil {
+			t.Fatalf("%v Got error %v.", te.name, err)
+		}
+		result, err := FromJSON(data)
+		if err != nil {
+			t.Errorf("%v Got error %v decoding %s.", te.name, err, data)
+			continue
+		}
+		if d, ok := DiffFirst(te.model, result); ok {
+			t.Errorf("%v Decoded %s with a difference, %v.", te.name, data, d)
+		}
+	}
+
+	linked := NewModelLinkedMap()
+	linked.Put(ModelString("$type"), ModelString("model.point"))
+	linked.Put(ModelString("value"), ModelInt(1))
+
+	data, err := json.Marshal(linked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := NewModelLinkedMap()
+	if err := json.Unmarshal(data, result); err != nil || !result.Equals(linked) {
+		t.Errorf("A linked map with a $type key should round trip, but got %v (%v).", result, err)
+	}
+}
+
+func TestFromJSON_TypeKey(t *testing.T) {
+	type test struct {
+		name  string
+		input string
+		want  Model
+	}
+
+	table := []test{
+		{
+			name:  "An object with other keys after $type should be a map.",
+			input: `{"$type":"model.point","id":1,"tags":["a"]}`,
+			want: ModelMap{
+				ModelString("$type"): ModelString("model.point"),
+				ModelString("id"):    ModelInt(1),
+				ModelString("tags"):  ModelSlice{ModelString("a")},
+			},
+		},
+		{
+			name:  "An object with only a $type key should be a map.",
+			input: `{"$type":"Person"}`,
+			want:  ModelMap{ModelString("$type"): ModelString("Person")},
+		},
+		{
+			name:  "An object with a $type that is not a string should be a map.",
+			input: `{"$type":1,"value":{"$type":"model.point","value":{"X":1,"Y":2}}}`,
+			want:  ModelMap{ModelString("$type"): ModelInt(1), ModelString("value"): point{X: 1, Y: 2}},
+		},
+	}
+
+	for _, te := range table {
+		result, err := FromJSON([]byte(te.input))
+		if err != nil {
+			t.Errorf("%v Got error %v.", te.name, err)
+			continue
+		}
+		if d, ok := DiffFirst(te.want, result); ok {
+			t.Errorf("%v Decoded with a difference, %v.", te.name, d)
+		}
+	}
+}
+
+func TestModelMap_JSON(t *testing.T) {
+	type document struct {
+		Fields ModelMap
+		Items  ModelSlice
+		At     ModelTime
+	}
+
+	original := document{
+		Fields: ModelMap{ModelString("a"): ModelSlice{ModelInt(1), point{X: 3}}},
+		Items:  ModelSlice{ModelString("x"), ModelMap{ModelString("y"): nil}},
+		At:     ModelTime(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded document
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if d, ok := DiffFirst(original.Fields, decoded.Fields); ok {
+		t.Errorf("ModelMap did not round trip, %v.", d)
+	}
+	if d, ok := DiffFirst(original.Items, decoded.Items); ok {
+		t.Errorf("ModelSlice did not round trip, %v.", d)
+	}
+	if !original.At.Equals(decoded.At) {
+		t.Errorf("ModelTime did not round trip, expected %v but got %v.", original.At, decoded.At)
+	}
+
+	var wrong ModelMap
+	if err := json.Unmarshal([]byte(`[1]`), &wrong); err == nil {
+		t.Error("Unmarshalling an array into a ModelMap should return an error.")
+	}
+}
+
+func TestModelLinkedMap_JSON(t *testing.T) {
+	lm := NewModelLinkedMap()
+	if err := json.Unmarshal([]byte(`{"z":1,"a":{"y":2,"b":3}}`), lm); err != nil {
+		t.Fatal(err)
+	}
+
+	if !lm.Keys().Equals(ModelSlice{ModelString("z"), ModelString("a")}) {
+		t.Errorf("Expected the keys in the order they were written but got %v.", lm.Keys())
+	}
+
+	nested, _ := lm.Get(ModelString("a"))
+	if keys := nested.(*ModelLinkedMap).Keys(); !keys.Equals(ModelSlice{ModelString("y"), ModelString("b")}) {
+		t.Errorf("Expected nested keys in the order they were written but got %v.", keys)
+	}
+
+	data, err := json.Marshal(lm)
+	if err != nil || string(data) != `{"z":1,"a":{"y":2,"b":3}}` {
+		t.Errorf("Expected the linked map to be written in order but got %s (%v).", data, err)
+	}
+}
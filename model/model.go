@@ -6,7 +6,11 @@ type Model interface {
 	Equals(Model) bool
 }
 
-// ModelsEqual checks if two given models are equal.
+// ModelsEqual checks if two given models are equal. Unlike calling Equals
+// directly, either model may be nil, and two nil models are equal.
 func ModelsEqual(m1, m2 Model) bool {
-	return (m1 == nil && m2 == nil) || (m1 != nil && m1.Equals(m2))
+	if m1 == nil || m2 == nil {
+		return m1 == nil && m2 == nil
+	}
+	return m1.Equals(m2)
 }
@@ -0,0 +1,42 @@
+package model
+
+import "strconv"
+
+// ModelBool is a Model for the type bool
+type ModelBool bool
+
+// Equals checks and returns 'true' if m is equal to mb
+func (mb ModelBool) Equals(m Model) bool {
+	return mb == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether mb is less than, equal
+// to or greater than the ModelBool m, where false is ordered before true.
+func (mb ModelBool) Compare(m Model) int {
+	other, ok := m.(ModelBool)
+	if !ok {
+		panic(incomparable(mb, m))
+	}
+	switch {
+	case mb == other:
+		return 0
+	case !bool(mb):
+		return -1
+	default:
+		return 1
+	}
+}
+
+// String returns "true" or "false".
+func (mb ModelBool) String() string {
+	return strconv.FormatBool(bool(mb))
+}
+
+// Hash returns the hash of mb.
+func (mb ModelBool) Hash() uint64 {
+	h := newFnv64(tagBool)
+	if mb {
+		return uint64(h.addByte(1))
+	}
+	return uint64(h.addByte(0))
+}
@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestModelBool_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelBool
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both values are equal and should return true.",
+			models: [2]ModelBool{
+				ModelBool(true),
+				ModelBool(true),
+			},
+			want: true,
+		},
+		{
+			name: "Both values are different and should return false.",
+			models: [2]ModelBool{
+				ModelBool(true),
+				ModelBool(false),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelBool_Compare(t *testing.T) {
+	if ModelBool(false).Compare(ModelBool(true)) != -1 || ModelBool(true).Compare(ModelBool(true)) != 0 {
+		t.Error("ModelBool should order false before true.")
+	}
+}
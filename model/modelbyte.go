@@ -1,5 +1,7 @@
 package model
 
+import "strconv"
+
 // ModelByte is a Model for the type byte
 type ModelByte byte
 
@@ -7,3 +9,28 @@ type ModelByte byte
 func (mb ModelByte) Equals(m Model) bool {
 	return mb == m
 }
+
+// Compare returns -1, 0 or 1 depending on whether mb is less than, equal
+// to or greater than the numeric model m.
+func (mb ModelByte) Compare(m Model) int {
+	return compareNumeric(mb, m)
+}
+
+// String returns the decimal representation of mb.
+func (mb ModelByte) String() string {
+	return strconv.Itoa(int(mb))
+}
+
+// Int64 converts mb into a ModelInt64.
+func (mb ModelByte) Int64() ModelInt64 { return ModelInt64(mb) }
+
+// Uint64 converts mb into a ModelUint64.
+func (mb ModelByte) Uint64() ModelUint64 { return ModelUint64(mb) }
+
+// Float64 converts mb into a ModelFloat64.
+func (mb ModelByte) Float64() ModelFloat64 { return ModelFloat64(mb) }
+
+// Hash returns the hash of mb.
+func (mb ModelByte) Hash() uint64 {
+	return uint64(newFnv64(tagByte).addByte(byte(mb)))
+}
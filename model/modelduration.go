@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// ModelDuration is a Model for the type time.Duration
+type ModelDuration time.Duration
+
+// Equals checks and returns 'true' if m is equal to md
+func (md ModelDuration) Equals(m Model) bool {
+	return md == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether md is shorter than,
+// equal to or longer than the ModelDuration m.
+func (md ModelDuration) Compare(m Model) int {
+	other, ok := m.(ModelDuration)
+	if !ok {
+		panic(incomparable(md, m))
+	}
+	return compareOrdered(md, other)
+}
+
+// String returns the duration formatted the same way as time.Duration,
+// such as "1h2m0.5s".
+func (md ModelDuration) String() string {
+	return time.Duration(md).String()
+}
+
+// Hash returns the hash of md.
+func (md ModelDuration) Hash() uint64 {
+	return uint64(newFnv64(tagDuration).addUint64(uint64(md)))
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModelDuration_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelDuration
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Check if two values that are equal return true",
+			models: [2]ModelDuration{
+				ModelDuration(90 * time.Second),
+				ModelDuration(90 * time.Second),
+			},
+			want: true,
+		},
+		{
+			name: "Two different values that are not equal should return false",
+			models: [2]ModelDuration{
+				ModelDuration(90 * time.Second),
+				ModelDuration(time.Second),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelDuration_Compare(t *testing.T) {
+	if result := ModelDuration(90 * time.Second).Compare(ModelDuration(90 * time.Second)); result != 0 {
+		t.Errorf("Equal values should compare as 0 but got %v.", result)
+	}
+
+	if result := ModelDuration(90 * time.Second).Compare(ModelDuration(time.Second)); result != 1 {
+		t.Errorf("Expected a comparison of 1 but got %v.", result)
+	}
+}
+
+func TestModelDuration_String(t *testing.T) {
+	if result := ModelDuration(90 * time.Second).String(); result != "1m30s" {
+		t.Errorf("Expected %q but got %q.", "1m30s", result)
+	}
+}
@@ -1,5 +1,7 @@
 package model
 
+import "strconv"
+
 // ModelFloat is a model of the 'float32' type
 type ModelFloat float32
 
@@ -7,3 +9,29 @@ type ModelFloat float32
 func (mf ModelFloat) Equals(m Model) bool {
 	return mf == m
 }
+
+// Compare returns -1, 0 or 1 depending on whether mf is less than, equal
+// to or greater than the numeric model m. NaN is ordered before every
+// other value.
+func (mf ModelFloat) Compare(m Model) int {
+	return compareNumeric(mf, m)
+}
+
+// String returns the shortest decimal representation of mf.
+func (mf ModelFloat) String() string {
+	return strconv.FormatFloat(float64(mf), 'g', -1, 32)
+}
+
+// Int64 converts mf into a ModelInt64, truncating any fraction.
+func (mf ModelFloat) Int64() ModelInt64 { return ModelInt64(mf) }
+
+// Uint64 converts mf into a ModelUint64, truncating any fraction.
+func (mf ModelFloat) Uint64() ModelUint64 { return ModelUint64(mf) }
+
+// Float64 converts mf into a ModelFloat64.
+func (mf ModelFloat) Float64() ModelFloat64 { return ModelFloat64(mf) }
+
+// Hash returns the hash of mf.
+func (mf ModelFloat) Hash() uint64 {
+	return uint64(newFnv64(tagFloat).addFloat(float64(mf)))
+}
@@ -0,0 +1,37 @@
+package model
+
+import "strconv"
+
+// ModelFloat64 is a Model for the type float64
+type ModelFloat64 float64
+
+// Equals checks and returns 'true' if m is equal to mf
+func (mf ModelFloat64) Equals(m Model) bool {
+	return mf == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether mf is less than, equal
+// to or greater than the numeric model m. NaN is ordered before every
+// other value.
+func (mf ModelFloat64) Compare(m Model) int {
+	return compareNumeric(mf, m)
+}
+
+// String returns the shortest decimal representation of mf.
+func (mf ModelFloat64) String() string {
+	return strconv.FormatFloat(float64(mf), 'g', -1, 64)
+}
+
+// Int64 converts mf into a ModelInt64, truncating any fraction.
+func (mf ModelFloat64) Int64() ModelInt64 { return ModelInt64(mf) }
+
+// Uint64 converts mf into a ModelUint64, truncating any fraction.
+func (mf ModelFloat64) Uint64() ModelUint64 { return ModelUint64(mf) }
+
+// Float64 converts mf into a ModelFloat64.
+func (mf ModelFloat64) Float64() ModelFloat64 { return mf }
+
+// Hash returns the hash of mf.
+func (mf ModelFloat64) Hash() uint64 {
+	return uint64(newFnv64(tagFloat64).addFloat(float64(mf)))
+}
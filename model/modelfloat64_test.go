@@ -0,0 +1,53 @@
+package model
+
+import "testing"
+
+func TestModelFloat64_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelFloat64
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Check if two values that are equal return true",
+			models: [2]ModelFloat64{
+				ModelFloat64(2.5),
+				ModelFloat64(2.5),
+			},
+			want: true,
+		},
+		{
+			name: "Two different values that are not equal should return false",
+			models: [2]ModelFloat64{
+				ModelFloat64(2.5),
+				ModelFloat64(-1),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelFloat64_Compare(t *testing.T) {
+	if result := ModelFloat64(2.5).Compare(ModelFloat64(2.5)); result != 0 {
+		t.Errorf("Equal values should compare as 0 but got %v.", result)
+	}
+
+	if result := ModelFloat64(2.5).Compare(ModelFloat64(-1)); result != 1 {
+		t.Errorf("Expected a comparison of 1 but got %v.", result)
+	}
+}
+
+func TestModelFloat64_String(t *testing.T) {
+	if result := ModelFloat64(2.5).String(); result != "2.5" {
+		t.Errorf("Expected %q but got %q.", "2.5", result)
+	}
+}
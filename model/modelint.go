@@ -1,5 +1,7 @@
 package model
 
+import "strconv"
+
 // ModelInt is a Model for the type int
 type ModelInt int
 
@@ -7,3 +9,28 @@ type ModelInt int
 func (mi ModelInt) Equals(m Model) bool {
 	return mi == m
 }
+
+// Compare returns -1, 0 or 1 depending on whether mi is less than, equal
+// to or greater than the numeric model m.
+func (mi ModelInt) Compare(m Model) int {
+	return compareNumeric(mi, m)
+}
+
+// String returns the decimal representation of mi.
+func (mi ModelInt) String() string {
+	return strconv.Itoa(int(mi))
+}
+
+// Int64 converts mi into a ModelInt64.
+func (mi ModelInt) Int64() ModelInt64 { return ModelInt64(mi) }
+
+// Uint64 converts mi into a ModelUint64.
+func (mi ModelInt) Uint64() ModelUint64 { return ModelUint64(mi) }
+
+// Float64 converts mi into a ModelFloat64.
+func (mi ModelInt) Float64() ModelFloat64 { return ModelFloat64(mi) }
+
+// Hash returns the hash of mi.
+func (mi ModelInt) Hash() uint64 {
+	return uint64(newFnv64(tagInt).addUint64(uint64(mi)))
+}
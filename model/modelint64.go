@@ -0,0 +1,36 @@
+package model
+
+import "strconv"
+
+// ModelInt64 is a Model for the type int64
+type ModelInt64 int64
+
+// Equals checks and returns 'true' if m is equal to mi
+func (mi ModelInt64) Equals(m Model) bool {
+	return mi == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether mi is less than, equal
+// to or greater than the numeric model m.
+func (mi ModelInt64) Compare(m Model) int {
+	return compareNumeric(mi, m)
+}
+
+// String returns the decimal representation of mi.
+func (mi ModelInt64) String() string {
+	return strconv.FormatInt(int64(mi), 10)
+}
+
+// Int64 converts mi into a ModelInt64.
+func (mi ModelInt64) Int64() ModelInt64 { return mi }
+
+// Uint64 converts mi into a ModelUint64.
+func (mi ModelInt64) Uint64() ModelUint64 { return ModelUint64(mi) }
+
+// Float64 converts mi into a ModelFloat64.
+func (mi ModelInt64) Float64() ModelFloat64 { return ModelFloat64(mi) }
+
+// Hash returns the hash of mi.
+func (mi ModelInt64) Hash() uint64 {
+	return uint64(newFnv64(tagInt64).addUint64(uint64(mi)))
+}
@@ -0,0 +1,97 @@
+package model
+
+// ModelLinkedMap is a Model for a map of Model keys and values that
+// remembers the order in which each key was first inserted.
+//
+// Unlike ModelMap, iterating over a ModelLinkedMap will always
+// visit the keys in the same order, which makes it useful when the
+// output must be stable. Keys are found using their hash, so any Model
+// can be used as a key, including a ModelSlice or ModelMap.
+type ModelLinkedMap struct {
+	keys   ModelSlice
+	values *HashMap
+}
+
+// NewModelLinkedMap creates and returns an empty ModelLinkedMap.
+func NewModelLinkedMap() *ModelLinkedMap {
+	return &ModelLinkedMap{
+		keys:   ModelSlice{},
+		values: NewHashMap(),
+	}
+}
+
+// Put associates the value v with the key k. If the key is already
+// present, the value is replaced and the key keeps its original position.
+func (lm *ModelLinkedMap) Put(k, v Model) {
+	if !lm.values.Contains(k) {
+		lm.keys = append(lm.keys, k)
+	}
+	lm.values.Put(k, v)
+}
+
+// Get returns the value associated with the key k and whether or not
+// the key was present in the map.
+func (lm *ModelLinkedMap) Get(k Model) (Model, bool) {
+	return lm.values.Get(k)
+}
+
+// Keys returns a ModelSlice of all the keys in the order that they were
+// first inserted.
+func (lm *ModelLinkedMap) Keys() ModelSlice {
+	keys := make(ModelSlice, len(lm.keys))
+	copy(keys, lm.keys)
+	return keys
+}
+
+// Len returns the total number of keys within the map.
+func (lm *ModelLinkedMap) Len() int {
+	return len(lm.keys)
+}
+
+// ForEach calls f on every key and value pair in insertion order.
+func (lm *ModelLinkedMap) ForEach(f func(k, v Model)) {
+	for _, k := range lm.keys {
+		v, _ := lm.values.Get(k)
+		f(k, v)
+	}
+}
+
+// Equals checks and returns 'true' if m is a ModelLinkedMap that contains
+// equal keys, in the same order, that are associated with equal values.
+func (lm *ModelLinkedMap) Equals(m Model) bool {
+	other, ok := m.(*ModelLinkedMap)
+	if !ok || other == nil || lm.Len() != other.Len() {
+		return false
+	}
+
+	for i, k := range lm.keys {
+		if !ModelsEqual(k, other.keys[i]) {
+			return false
+		}
+		v1, _ := lm.values.Get(k)
+		v2, _ := other.values.Get(k)
+		if !ModelsEqual(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns the hash of lm, which depends on the order of the keys.
+func (lm *ModelLinkedMap) Hash() uint64 {
+	h := newFnv64(tagLinkedMap)
+	lm.ForEach(func(k, v Model) {
+		h = h.addUint64(HashModel(k)).addUint64(HashModel(v))
+	})
+	return uint64(h)
+}
+
+// Entries returns a ModelSlice that holds a ModelPair of every key and value
+// within the map, in the order the keys were first added.
+func (lm *ModelLinkedMap) Entries() ModelSlice {
+	entries := make(ModelSlice, 0, lm.Len())
+	lm.ForEach(func(k, v Model) {
+		entries = append(entries, NewModelPair(k, v))
+	})
+	return entries
+}
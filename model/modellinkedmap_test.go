@@ -0,0 +1,72 @@
+package model
+
+import "testing"
+
+func TestModelLinkedMap_Put(t *testing.T) {
+	lm := NewModelLinkedMap()
+	lm.Put(ModelInt(3), ModelInt(1))
+	lm.Put(ModelInt(1), ModelInt(2))
+	lm.Put(ModelInt(3), ModelInt(3))
+
+	if !lm.Keys().Equals(ModelSlice{ModelInt(3), ModelInt(1)}) {
+		t.Errorf("Expected keys to remain in insertion order but got %v.", lm.Keys())
+	}
+
+	if v, ok := lm.Get(ModelInt(3)); !ok || !v.Equals(ModelInt(3)) {
+		t.Errorf("Expected existing key to be replaced with 3 but got %v.", v)
+	}
+
+	if _, ok := lm.Get(ModelInt(2)); ok {
+		t.Error("Expected missing key to not be found.")
+	}
+}
+
+func TestModelLinkedMap_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]*ModelLinkedMap
+		want   bool
+	}
+
+	build := func(pairs ...Model) *ModelLinkedMap {
+		lm := NewModelLinkedMap()
+		for i := 0; i < len(pairs); i += 2 {
+			lm.Put(pairs[i], pairs[i+1])
+		}
+		return lm
+	}
+
+	table := []test{
+		{
+			name: "Both maps have the same keys in the same order, should return true.",
+			models: [2]*ModelLinkedMap{
+				build(ModelInt(1), ModelInt(1), ModelInt(2), ModelInt(2)),
+				build(ModelInt(1), ModelInt(1), ModelInt(2), ModelInt(2)),
+			},
+			want: true,
+		},
+		{
+			name: "Both maps have the same keys in a different order, should return false.",
+			models: [2]*ModelLinkedMap{
+				build(ModelInt(1), ModelInt(1), ModelInt(2), ModelInt(2)),
+				build(ModelInt(2), ModelInt(2), ModelInt(1), ModelInt(1)),
+			},
+			want: false,
+		},
+		{
+			name: "Both maps have the same keys but different values, should return false.",
+			models: [2]*ModelLinkedMap{
+				build(ModelInt(1), ModelInt(1)),
+				build(ModelInt(1), ModelInt(2)),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
@@ -1,23 +1,105 @@
 package model
 
+import "reflect"
+
 // ModelMap is a Model for the type map[Model]Model
 type ModelMap map[Model]Model
 
-// Equals checks and returns 'true' if m is equal to mm
+// Equals checks and returns 'true' if m is a ModelMap with exactly the
+// same keys as mm, where each key holds an equal value. Nested maps and
+// slices are compared deeply, and nil values are allowed. Keys are matched
+// using Equals as well, so a ModelTime key matches the same instant in
+// another location.
 func (mm ModelMap) Equals(m Model) bool {
 	mappedModel, ok := m.(ModelMap)
-	if ok == false {
+	if !ok || len(mappedModel) != len(mm) {
 		return false
 	}
 
-	for key, val := range mappedModel {
-		if val2, ok := mm[key]; ok {
-			if result := val.Equals(val2); !result {
-				return false
-			}
-		} else {
+	var missed ModelSlice
+	for key, val := range mm {
+		val2, ok := mappedModel[key]
+		if !ok {
+			missed = append(missed, key)
+			continue
+		}
+		if !ModelsEqual(val, val2) {
+			return false
+		}
+	}
+	if len(missed) == 0 {
+		return true
+	}
+
+	// The keys that were not found using ==, on both sides, are matched
+	// with each other using Equals.
+	var unmatched ModelSlice
+	for key := range mappedModel {
+		if _, ok := mm[key]; !ok {
+			unmatched = append(unmatched, key)
+		}
+	}
+	for _, key := range missed {
+		i := matchKey(unmatched, key, mm[key], mappedModel)
+		if i < 0 {
 			return false
 		}
+		unmatched = append(unmatched[:i], unmatched[i+1:]...)
 	}
 	return true
 }
+
+// matchKey returns the index of the key within keys that equals key and
+// holds a value within mm that equals val, or -1 if there is none.
+func matchKey(keys ModelSlice, key, val Model, mm ModelMap) int {
+	for i, k := range keys {
+		if ModelsEqual(key, k) && ModelsEqual(val, mm[k]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Hash returns the hash of mm, which does not depend on the order in which
+// the keys are visited.
+func (mm ModelMap) Hash() uint64 {
+	return hashEntries(tagMap, len(mm), func(f func(k, v Model)) {
+		for k, v := range mm {
+			f(k, v)
+		}
+	})
+}
+
+// Entries returns a ModelSlice that holds a ModelPair of every key and value
+// within the map. The order of the entries is not specified.
+func (mm ModelMap) Entries() ModelSlice {
+	entries := make(ModelSlice, 0, len(mm))
+	for k, v := range mm {
+		entries = append(entries, NewModelPair(k, v))
+	}
+	return entries
+}
+
+// IsMapKey returns 'true' if k can be used as the key of a ModelMap. The key
+// must be hashable, and the pointer-typed collections such as a ModelSet or
+// HashMap are rejected, since a ModelMap would compare them by identity
+// rather than by their contents. A ModelPair or ModelTuple is checked by the
+// models that it holds. Use a HashMap for any other key.
+func IsMapKey(k Model) bool {
+	switch key := k.(type) {
+	case nil:
+		return true
+	case *ModelSet, *ModelLinkedMap, *HashMap, *HashSet:
+		return false
+	case ModelPair:
+		return IsMapKey(key.First) && IsMapKey(key.Second)
+	case ModelTuple:
+		for i := 0; i < key.Len(); i++ {
+			if !IsMapKey(key.Get(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.TypeOf(k).Comparable()
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 )
 
 func TestModelMap_Equals(t *testing.T) {
@@ -11,6 +12,11 @@ func TestModelMap_Equals(t *testing.T) {
 		want   bool
 	}
 
+	instant := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	utc := ModelTime(instant)
+	local := ModelTime(instant.In(time.FixedZone("EST", -5*60*60)))
+	later := ModelTime(instant.Add(time.Hour))
+
 	table := []test{
 		{
 			name: "Both ModelMaps are equal and should return true.",
@@ -54,11 +60,103 @@ func TestModelMap_Equals(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "The first ModelMap is a subset of the second, should return false.",
+			models: [2]ModelMap{
+				{
+					ModelInt(1): ModelInt(1),
+				},
+				{
+					ModelInt(1): ModelInt(1),
+					ModelInt(2): ModelInt(2),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "The second ModelMap is a subset of the first, should return false.",
+			models: [2]ModelMap{
+				{
+					ModelInt(1): ModelInt(1),
+					ModelInt(2): ModelInt(2),
+				},
+				{
+					ModelInt(1): ModelInt(1),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Both ModelMaps hold nil and nested values, should return true.",
+			models: [2]ModelMap{
+				{
+					ModelInt(1): nil,
+					ModelInt(2): ModelSlice{ModelMap{ModelInt(3): nil}},
+				},
+				{
+					ModelInt(1): nil,
+					ModelInt(2): ModelSlice{ModelMap{ModelInt(3): nil}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Time keys of the same instant in different locations, should return true.",
+			models: [2]ModelMap{
+				{utc: ModelInt(1), later: ModelInt(2)},
+				{local: ModelInt(1), later: ModelInt(2)},
+			},
+			want: true,
+		},
+		{
+			name: "Time keys of the same instant holding different values, should return false.",
+			models: [2]ModelMap{
+				{utc: ModelInt(1)},
+				{local: ModelInt(2)},
+			},
+			want: false,
+		},
+		{
+			name: "Two keys of the same instant cannot both match a single key, should return false.",
+			models: [2]ModelMap{
+				{utc: ModelInt(1), local: ModelInt(1)},
+				{utc: ModelInt(1), later: ModelInt(1)},
+			},
+			want: false,
+		},
+		{
+			name: "Set keys holding the same models, should return true.",
+			models: [2]ModelMap{
+				{NewModelSet(ModelInt(1), ModelInt(2)): ModelString("a")},
+				{NewModelSet(ModelInt(2), ModelInt(1)): ModelString("a")},
+			},
+			want: true,
+		},
+		{
+			name: "Set keys holding different models, should return false.",
+			models: [2]ModelMap{
+				{NewModelSet(ModelInt(1)): ModelString("a")},
+				{NewModelSet(ModelInt(2)): ModelString("a")},
+			},
+			want: false,
+		},
+		{
+			name: "Only one ModelMap holds a nil value, should return false.",
+			models: [2]ModelMap{
+				{
+					ModelInt(1): nil,
+				},
+				{
+					ModelInt(1): ModelInt(1),
+				},
+			},
+			want: false,
+		},
 	}
 
 	for _, te := range table {
 		model1, model2 := te.models[0], te.models[1]
-		if model1.Equals(model2) != te.want {
+		if model1.Equals(model2) != te.want || model2.Equals(model1) != te.want {
 			t.Error(te.name)
 		}
 	}
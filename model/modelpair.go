@@ -0,0 +1,49 @@
+package model
+
+import "fmt"
+
+// ModelPair is a Model that holds two models, such as the entries of a map
+// or the models of two streams that have been zipped together.
+//
+// A ModelPair can be used as the key of a ModelMap, such as by GroupingBy,
+// as long as both of its models can be used as keys.
+type ModelPair struct {
+	First  Model
+	Second Model
+}
+
+// NewModelPair creates and returns a ModelPair of the two models.
+func NewModelPair(first, second Model) ModelPair {
+	return ModelPair{First: first, Second: second}
+}
+
+// Equals checks and returns 'true' if m is a ModelPair where both models
+// are equal to the models of mp.
+func (mp ModelPair) Equals(m Model) bool {
+	other, ok := m.(ModelPair)
+	return ok && ModelsEqual(mp.First, other.First) && ModelsEqual(mp.Second, other.Second)
+}
+
+// Compare orders the pairs by their first models, and then by their second
+// models. Both models must implement Ordered.
+func (mp ModelPair) Compare(m Model) int {
+	other, ok := m.(ModelPair)
+	if !ok {
+		panic(incomparable(mp, m))
+	}
+
+	if c := CompareModels(mp.First, other.First); c != 0 {
+		return c
+	}
+	return CompareModels(mp.Second, other.Second)
+}
+
+// String returns the pair formatted as (first, second).
+func (mp ModelPair) String() string {
+	return fmt.Sprintf("(%v, %v)", mp.First, mp.Second)
+}
+
+// Hash returns the hash of mp, which depends on the order of the models.
+func (mp ModelPair) Hash() uint64 {
+	return uint64(newFnv64(tagPair).addUint64(HashModel(mp.First)).addUint64(HashModel(mp.Second)))
+}
@@ -0,0 +1,80 @@
+package model
+
+import "testing"
+
+func TestModelPair_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelPair
+		want   bool
+	}
+
+	table := []test{
+		{
+			name:   "Both pairs hold equal nested models, should return true.",
+			models: [2]ModelPair{NewModelPair(ModelSlice{ModelInt(1)}, nil), NewModelPair(ModelSlice{ModelInt(1)}, nil)},
+			want:   true,
+		},
+		{
+			name:   "Both pairs hold the same models in a different order, should return false.",
+			models: [2]ModelPair{NewModelPair(ModelInt(1), ModelInt(2)), NewModelPair(ModelInt(2), ModelInt(1))},
+			want:   false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+		if te.want && model1.Hash() != model2.Hash() {
+			t.Errorf("%v Equal pairs should have the same hash.", te.name)
+		}
+	}
+}
+
+func TestModelPair_Compare(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelPair
+		want   int
+	}
+
+	table := []test{
+		{
+			name:   "Pairs with a smaller first model should be ordered first.",
+			models: [2]ModelPair{NewModelPair(ModelInt(1), ModelInt(9)), NewModelPair(ModelInt(2), ModelInt(0))},
+			want:   -1,
+		},
+		{
+			name:   "Pairs with equal first models should be ordered by their second model.",
+			models: [2]ModelPair{NewModelPair(ModelInt(1), ModelString("b")), NewModelPair(ModelInt(1), ModelString("a"))},
+			want:   1,
+		},
+	}
+
+	for _, te := range table {
+		if result := te.models[0].Compare(te.models[1]); result != te.want {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, result)
+		}
+	}
+}
+
+func TestModelPair_MapKey(t *testing.T) {
+	counts := ModelMap{}
+	for _, p := range []ModelPair{
+		NewModelPair(ModelString("a"), ModelInt(1)),
+		NewModelPair(ModelString("a"), ModelInt(1)),
+		NewModelPair(ModelString("b"), ModelInt(1)),
+	} {
+		if c, ok := counts[p]; ok {
+			counts[p] = c.(ModelInt) + 1
+		} else {
+			counts[p] = ModelInt(1)
+		}
+	}
+
+	if len(counts) != 2 || counts[NewModelPair(ModelString("a"), ModelInt(1))] != ModelInt(2) {
+		t.Errorf("Expected equal pairs to be the same ModelMap key but got %v.", counts)
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+// ModelRune is a Model for the type rune
+type ModelRune rune
+
+// Equals checks and returns 'true' if m is equal to mr
+func (mr ModelRune) Equals(m Model) bool {
+	return mr == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether the code point of mr
+// is less than, equal to or greater than the ModelRune m.
+func (mr ModelRune) Compare(m Model) int {
+	other, ok := m.(ModelRune)
+	if !ok {
+		panic(incomparable(mr, m))
+	}
+	return compareOrdered(mr, other)
+}
+
+// String returns the character that mr represents.
+func (mr ModelRune) String() string {
+	return string(rune(mr))
+}
+
+// Hash returns the hash of mr.
+func (mr ModelRune) Hash() uint64 {
+	return uint64(newFnv64(tagRune).addUint64(uint64(mr)))
+}
@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestModelRune_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelRune
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both values are equal and should return true.",
+			models: [2]ModelRune{
+				ModelRune('é'),
+				ModelRune('é'),
+			},
+			want: true,
+		},
+		{
+			name: "Both values are different and should return false.",
+			models: [2]ModelRune{
+				ModelRune('é'),
+				ModelRune('e'),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelRune_String(t *testing.T) {
+	if result := ModelRune('é').String(); result != "é" {
+		t.Errorf("Expected the character but got %q.", result)
+	}
+}
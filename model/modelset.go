@@ -0,0 +1,147 @@
+package model
+
+import "sort"
+
+// ModelSet is a Model for a collection of unique Models. A model is only
+// ever added once, even if it is added several times.
+//
+// The models within the set are kept in the order they were first added,
+// so that iterating over the set is always predictable.
+//
+// Models are found using their hash, so any Model can be placed in the
+// set, including a ModelSlice or ModelMap.
+type ModelSet struct {
+	order ModelSlice
+	items *HashSet
+}
+
+// NewModelSet creates and returns a ModelSet containing all the given models.
+func NewModelSet(models ...Model) *ModelSet {
+	set := &ModelSet{
+		order: ModelSlice{},
+		items: NewHashSet(),
+	}
+	for _, m := range models {
+		set.Add(m)
+	}
+	return set
+}
+
+// Add places m into the set and returns 'true' if the model was not
+// already in the set.
+func (ms *ModelSet) Add(m Model) bool {
+	if !ms.items.Add(m) {
+		return false
+	}
+	ms.order = append(ms.order, m)
+	return true
+}
+
+// Remove takes m out of the set and returns 'true' if the model was
+// present in the set.
+func (ms *ModelSet) Remove(m Model) bool {
+	if !ms.items.Remove(m) {
+		return false
+	}
+	for i, v := range ms.order {
+		if ModelsEqual(v, m) {
+			ms.order = append(ms.order[:i], ms.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Contains checks and returns 'true' if m is within the set.
+func (ms *ModelSet) Contains(m Model) bool {
+	return ms.items.Contains(m)
+}
+
+// Len returns the total number of models within the set.
+func (ms *ModelSet) Len() int {
+	return len(ms.order)
+}
+
+// Slice returns a ModelSlice of all the models in the order that they
+// were first added to the set.
+func (ms *ModelSet) Slice() ModelSlice {
+	slice := make(ModelSlice, len(ms.order))
+	copy(slice, ms.order)
+	return slice
+}
+
+// Equals checks and returns 'true' if m is a ModelSet that contains
+// exactly the same models as ms, regardless of their order.
+func (ms *ModelSet) Equals(m Model) bool {
+	other, ok := m.(*ModelSet)
+	if !ok || other == nil || ms.Len() != other.Len() {
+		return false
+	}
+
+	for _, v := range ms.order {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns the hash of ms, which does not depend on the order in
+// which the models were added.
+func (ms *ModelSet) Hash() uint64 {
+	return ms.items.Hash()
+}
+
+// Union returns a new ModelSet of the models that are in either ms or other.
+// The models of ms come first, followed by the models only in other.
+func (ms *ModelSet) Union(other *ModelSet) *ModelSet {
+	union := NewModelSet(ms.order...)
+	for _, m := range other.order {
+		union.Add(m)
+	}
+	return union
+}
+
+// Intersection returns a new ModelSet of the models that are in both ms and
+// other, in the order of ms.
+func (ms *ModelSet) Intersection(other *ModelSet) *ModelSet {
+	intersection := NewModelSet()
+	for _, m := range ms.order {
+		if other.Contains(m) {
+			intersection.Add(m)
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new ModelSet of the models that are in ms but not in
+// other, in the order of ms.
+func (ms *ModelSet) Difference(other *ModelSet) *ModelSet {
+	difference := NewModelSet()
+	for _, m := range ms.order {
+		if !other.Contains(m) {
+			difference.Add(m)
+		}
+	}
+	return difference
+}
+
+// Compare orders the sets by sorting the models of each by their natural
+// order, and then comparing them in the same way as a ModelTuple. The
+// models must implement Ordered.
+func (ms *ModelSet) Compare(m Model) int {
+	other, ok := m.(*ModelSet)
+	if !ok || other == nil {
+		panic(incomparable(ms, m))
+	}
+	return NewModelTuple(ms.sorted()...).Compare(NewModelTuple(other.sorted()...))
+}
+
+// sorted returns the models of the set sorted by their natural order.
+func (ms *ModelSet) sorted() ModelSlice {
+	slice := ms.Slice()
+	sort.SliceStable(slice, func(i, j int) bool {
+		return CompareModels(slice[i], slice[j]) < 0
+	})
+	return slice
+}
@@ -0,0 +1,143 @@
+package model
+
+import "testing"
+
+func TestModelSet_Add(t *testing.T) {
+	set := NewModelSet(ModelInt(2), ModelInt(1), ModelInt(2))
+
+	if set.Len() != 2 {
+		t.Errorf("Expected the set to contain 2 models but it has %v.", set.Len())
+	}
+
+	if !set.Slice().Equals(ModelSlice{ModelInt(2), ModelInt(1)}) {
+		t.Errorf("Expected models in the order they were added but got %v.", set.Slice())
+	}
+
+	if set.Add(ModelInt(1)) {
+		t.Error("Adding a model that is already in the set should return false.")
+	}
+}
+
+func TestModelSet_Remove(t *testing.T) {
+	set := NewModelSet(ModelInt(1), ModelInt(2), ModelInt(3))
+
+	if !set.Remove(ModelInt(2)) {
+		t.Error("Removing a model within the set should return true.")
+	}
+
+	if set.Contains(ModelInt(2)) || !set.Slice().Equals(ModelSlice{ModelInt(1), ModelInt(3)}) {
+		t.Errorf("Removed model is still within the set %v.", set.Slice())
+	}
+
+	if set.Remove(ModelInt(2)) {
+		t.Error("Removing a model that is not in the set should return false.")
+	}
+}
+
+func TestModelSet_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]*ModelSet
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both sets contain the same models in a different order, should return true.",
+			models: [2]*ModelSet{
+				NewModelSet(ModelInt(1), ModelInt(2)),
+				NewModelSet(ModelInt(2), ModelInt(1)),
+			},
+			want: true,
+		},
+		{
+			name: "Both sets contain different models, should return false.",
+			models: [2]*ModelSet{
+				NewModelSet(ModelInt(1), ModelInt(2)),
+				NewModelSet(ModelInt(1), ModelInt(3)),
+			},
+			want: false,
+		},
+		{
+			name: "One set contains more models than the other, should return false.",
+			models: [2]*ModelSet{
+				NewModelSet(ModelInt(1)),
+				NewModelSet(ModelInt(1), ModelInt(2)),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelSet_CompositeModels(t *testing.T) {
+	set := NewModelSet(ModelSlice{ModelInt(1)}, ModelMap{ModelString("a"): ModelInt(1)}, ModelSlice{ModelInt(1)})
+
+	if set.Len() != 2 {
+		t.Errorf("Expected the set to contain 2 models but it has %v.", set.Len())
+	}
+
+	if !set.Contains(ModelMap{ModelString("a"): ModelInt(1)}) {
+		t.Error("Expected the set to contain the map model.")
+	}
+}
+
+func TestModelSet_Operations(t *testing.T) {
+	a := NewModelSet(ModelInt(1), ModelInt(2), ModelInt(3))
+	b := NewModelSet(ModelInt(4), ModelInt(3), ModelInt(2))
+
+	type test struct {
+		name   string
+		result *ModelSet
+		want   ModelSlice
+	}
+
+	table := []test{
+		{name: "Union should hold the models of both sets.", result: a.Union(b), want: ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3), ModelInt(4)}},
+		{name: "Intersection should hold the models in both sets.", result: a.Intersection(b), want: ModelSlice{ModelInt(2), ModelInt(3)}},
+		{name: "Difference should hold the models only in the first set.", result: a.Difference(b), want: ModelSlice{ModelInt(1)}},
+	}
+
+	for _, te := range table {
+		if !te.result.Slice().Equals(te.want) {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, te.result.Slice())
+		}
+	}
+
+	if a.Len() != 3 || b.Len() != 3 {
+		t.Error("Set operations should not change the original sets.")
+	}
+}
+
+func TestModelSet_Compare(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]*ModelSet
+		want   int
+	}
+
+	table := []test{
+		{
+			name:   "Sets with the same models in a different order should be equal in order.",
+			models: [2]*ModelSet{NewModelSet(ModelInt(2), ModelInt(1)), NewModelSet(ModelInt(1), ModelInt(2))},
+			want:   0,
+		},
+		{
+			name:   "Sets should be ordered by their smallest model that differs.",
+			models: [2]*ModelSet{NewModelSet(ModelInt(3), ModelInt(1)), NewModelSet(ModelInt(2), ModelInt(1))},
+			want:   1,
+		},
+	}
+
+	for _, te := range table {
+		if result := te.models[0].Compare(te.models[1]); result != te.want {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, result)
+		}
+	}
+}
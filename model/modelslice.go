@@ -3,18 +3,28 @@ package model
 // ModelSlice is a Model for the type []Model
 type ModelSlice []Model
 
-// Equals checks and returns 'true' if m is equal to ms
+// Equals checks and returns 'true' if m is a ModelSlice of the same
+// length as ms, where each position holds an equal model. Nested maps and
+// slices are compared deeply, and nil models are allowed.
 func (ms ModelSlice) Equals(m Model) bool {
 	modelSlice, ok := m.(ModelSlice)
-	if ok == false || len(modelSlice) != len(ms) {
+	if !ok || len(modelSlice) != len(ms) {
 		return false
 	}
 
-	for i, _ := range ms {
-		m1, m2 := modelSlice[i], ms[i]
-		if !m1.Equals(m2) {
+	for i := range ms {
+		if !ModelsEqual(ms[i], modelSlice[i]) {
 			return false
 		}
 	}
 	return true
 }
+
+// Hash returns the hash of ms, which depends on the order of the models.
+func (ms ModelSlice) Hash() uint64 {
+	h := newFnv64(tagSlice)
+	for _, m := range ms {
+		h = h.addUint64(HashModel(m))
+	}
+	return uint64(h)
+}
@@ -46,11 +46,35 @@ func TestModelSlice_Equals(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Both slices hold nil and nested values, should return true.",
+			models: [2]ModelSlice{
+				{
+					nil, ModelSlice{ModelInt(1), nil},
+				},
+				{
+					nil, ModelSlice{ModelInt(1), nil},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Only one slice holds a nil value, should return false.",
+			models: [2]ModelSlice{
+				{
+					ModelInt(1), nil,
+				},
+				{
+					ModelInt(1), ModelInt(2),
+				},
+			},
+			want: false,
+		},
 	}
 
 	for _, te := range table {
 		model1, model2 := te.models[0], te.models[1]
-		if model1.Equals(model2) != te.want {
+		if model1.Equals(model2) != te.want || model2.Equals(model1) != te.want {
 			t.Error(te.name)
 		}
 	}
@@ -0,0 +1,29 @@
+package model
+
+// ModelString is a Model for the type string
+type ModelString string
+
+// Equals checks and returns 'true' if m is equal to ms
+func (ms ModelString) Equals(m Model) bool {
+	return ms == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether ms is lexically less
+// than, equal to or greater than the ModelString m.
+func (ms ModelString) Compare(m Model) int {
+	other, ok := m.(ModelString)
+	if !ok {
+		panic(incomparable(ms, m))
+	}
+	return compareOrdered(ms, other)
+}
+
+// String returns ms as a string.
+func (ms ModelString) String() string {
+	return string(ms)
+}
+
+// Hash returns the hash of ms.
+func (ms ModelString) Hash() uint64 {
+	return uint64(newFnv64(tagString).addString(string(ms)))
+}
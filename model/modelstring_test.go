@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestModelString_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelString
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both values are equal and should return true.",
+			models: [2]ModelString{
+				ModelString("fun"),
+				ModelString("fun"),
+			},
+			want: true,
+		},
+		{
+			name: "Both values are different and should return false.",
+			models: [2]ModelString{
+				ModelString("fun"),
+				ModelString("go"),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelString_Compare(t *testing.T) {
+	if ModelString("a").Compare(ModelString("b")) != -1 || ModelString("b").Compare(ModelString("a")) != 1 {
+		t.Error("ModelString should be ordered lexically.")
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// ModelTime is a Model for the type time.Time
+type ModelTime time.Time
+
+// Equals checks and returns 'true' if m is a ModelTime that represents
+// the same instant as mt, even if their locations differ.
+func (mt ModelTime) Equals(m Model) bool {
+	other, ok := m.(ModelTime)
+	return ok && time.Time(mt).Equal(time.Time(other))
+}
+
+// Compare returns -1, 0 or 1 depending on whether mt is before, equal to
+// or after the ModelTime m.
+func (mt ModelTime) Compare(m Model) int {
+	other, ok := m.(ModelTime)
+	if !ok {
+		panic(incomparable(mt, m))
+	}
+
+	t1, t2 := time.Time(mt), time.Time(other)
+	switch {
+	case t1.Before(t2):
+		return -1
+	case t1.After(t2):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String returns the time formatted the same way as time.Time.
+func (mt ModelTime) String() string {
+	return time.Time(mt).String()
+}
+
+// Hash returns the hash of the instant that mt represents, so that equal
+// times in different locations produce the same hash.
+func (mt ModelTime) Hash() uint64 {
+	t := time.Time(mt)
+	return uint64(newFnv64(tagTime).addUint64(uint64(t.Unix())).addUint64(uint64(t.Nanosecond())))
+}
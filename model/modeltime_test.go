@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModelTime_Equals(t *testing.T) {
+	instant := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	type test struct {
+		name   string
+		models [2]ModelTime
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "The same instant in different locations should return true.",
+			models: [2]ModelTime{
+				ModelTime(instant),
+				ModelTime(instant.In(time.FixedZone("EST", -5*60*60))),
+			},
+			want: true,
+		},
+		{
+			name: "Two different instants should return false.",
+			models: [2]ModelTime{
+				ModelTime(instant),
+				ModelTime(instant.Add(time.Second)),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelTime_Compare(t *testing.T) {
+	earlier := ModelTime(time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC))
+	later := ModelTime(time.Date(2021, 5, 2, 12, 0, 0, 0, time.UTC))
+
+	if earlier.Compare(later) != -1 || later.Compare(earlier) != 1 || earlier.Compare(earlier) != 0 {
+		t.Error("ModelTime should be ordered from the earliest to the latest instant.")
+	}
+}
+
+func TestModelTime_String(t *testing.T) {
+	instant := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+	if result := ModelTime(instant).String(); result != instant.String() {
+		t.Errorf("Expected %q but got %q.", instant.String(), result)
+	}
+}
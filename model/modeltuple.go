@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// modelType is the reflect.Type of the Model interface.
+var modelType = reflect.TypeOf((*Model)(nil)).Elem()
+
+// ModelTuple is a Model that holds a fixed number of models, such as a key
+// made up of several fields.
+//
+// The models are held within an array, rather than a slice, so that a
+// ModelTuple can be used as the key of a ModelMap, such as by GroupingBy,
+// as long as all of its models can be used as keys. The zero value is an
+// empty tuple.
+type ModelTuple struct {
+	// items holds a [n]Model array, or nil when the tuple is empty.
+	items any
+}
+
+// NewModelTuple creates and returns a ModelTuple of the given models.
+func NewModelTuple(models ...Model) ModelTuple {
+	if len(models) == 0 {
+		return ModelTuple{}
+	}
+
+	// Each model is set on its own, rather than copying the whole slice, so
+	// that reflect stores a copy of the model's value on the heap.
+	array := reflect.New(reflect.ArrayOf(len(models), modelType)).Elem()
+	for i, m := range models {
+		if m != nil {
+			array.Index(i).Set(reflect.ValueOf(m))
+		}
+	}
+	return ModelTuple{items: array.Interface()}
+}
+
+// Len returns the number of models within the tuple.
+func (mt ModelTuple) Len() int {
+	if mt.items == nil {
+		return 0
+	}
+	return reflect.ValueOf(mt.items).Len()
+}
+
+// Get returns the model at the index i, and panics if i is out of range.
+func (mt ModelTuple) Get(i int) Model {
+	if i < 0 || i >= mt.Len() {
+		panic("model: tuple index out of range")
+	}
+	m, _ := reflect.ValueOf(mt.items).Index(i).Interface().(Model)
+	return m
+}
+
+// Slice returns a ModelSlice of the models within the tuple.
+func (mt ModelTuple) Slice() ModelSlice {
+	slice := make(ModelSlice, mt.Len())
+	if len(slice) > 0 {
+		reflect.Copy(reflect.ValueOf(slice), reflect.ValueOf(mt.items))
+	}
+	return slice
+}
+
+// Equals checks and returns 'true' if m is a ModelTuple of the same length
+// as mt, where each position holds an equal model.
+func (mt ModelTuple) Equals(m Model) bool {
+	other, ok := m.(ModelTuple)
+	return ok && mt.Slice().Equals(other.Slice())
+}
+
+// Compare orders the tuples by comparing their models in order, where a
+// shorter tuple is ordered before a longer one that begins with the same
+// models. The models must implement Ordered.
+func (mt ModelTuple) Compare(m Model) int {
+	other, ok := m.(ModelTuple)
+	if !ok {
+		panic(incomparable(mt, m))
+	}
+
+	s1, s2 := mt.Slice(), other.Slice()
+	for i := 0; i < len(s1) && i < len(s2); i++ {
+		if c := CompareModels(s1[i], s2[i]); c != 0 {
+			return c
+		}
+	}
+	return compareOrdered(len(s1), len(s2))
+}
+
+// String returns the tuple formatted as (m1, m2, ...).
+func (mt ModelTuple) String() string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for i, m := range mt.Slice() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, m)
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// Hash returns the hash of mt, which depends on the order of the models.
+func (mt ModelTuple) Hash() uint64 {
+	h := newFnv64(tagTuple)
+	for _, m := range mt.Slice() {
+		h = h.addUint64(HashModel(m))
+	}
+	return uint64(h)
+}
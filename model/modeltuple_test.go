@@ -0,0 +1,93 @@
+package model
+
+import "testing"
+
+func TestModelTuple_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelTuple
+		want   bool
+	}
+
+	table := []test{
+		{
+			name:   "Both tuples hold equal models, should return true.",
+			models: [2]ModelTuple{NewModelTuple(ModelInt(1), ModelString("a"), nil), NewModelTuple(ModelInt(1), ModelString("a"), nil)},
+			want:   true,
+		},
+		{
+			name:   "Both tuples hold equal nested slices, should return true.",
+			models: [2]ModelTuple{NewModelTuple(ModelSlice{ModelInt(1)}), NewModelTuple(ModelSlice{ModelInt(1)})},
+			want:   true,
+		},
+		{
+			name:   "The tuples have a different length, should return false.",
+			models: [2]ModelTuple{NewModelTuple(ModelInt(1)), NewModelTuple(ModelInt(1), ModelInt(2))},
+			want:   false,
+		},
+		{
+			name:   "Both tuples are empty, should return true.",
+			models: [2]ModelTuple{NewModelTuple(), {}},
+			want:   true,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want || model2.Equals(model1) != te.want {
+			t.Error(te.name)
+		}
+		if te.want && model1.Hash() != model2.Hash() {
+			t.Errorf("%v Equal tuples should have the same hash.", te.name)
+		}
+	}
+}
+
+func TestModelTuple_Compare(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelTuple
+		want   int
+	}
+
+	table := []test{
+		{
+			name:   "Tuples should be ordered by the first model that differs.",
+			models: [2]ModelTuple{NewModelTuple(ModelInt(1), ModelInt(5)), NewModelTuple(ModelInt(1), ModelInt(3))},
+			want:   1,
+		},
+		{
+			name:   "A shorter tuple should be ordered before a longer one with the same start.",
+			models: [2]ModelTuple{NewModelTuple(ModelInt(1)), NewModelTuple(ModelInt(1), ModelInt(0))},
+			want:   -1,
+		},
+		{
+			name:   "Tuples with equal models should be equal in order.",
+			models: [2]ModelTuple{NewModelTuple(ModelString("a")), NewModelTuple(ModelString("a"))},
+			want:   0,
+		},
+	}
+
+	for _, te := range table {
+		if result := te.models[0].Compare(te.models[1]); result != te.want {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, result)
+		}
+	}
+}
+
+func TestModelTuple_Access(t *testing.T) {
+	tuple := NewModelTuple(ModelInt(1), nil, ModelString("c"))
+
+	if tuple.Len() != 3 || tuple.Get(1) != nil || tuple.Get(2) != ModelString("c") {
+		t.Errorf("Expected the models to be accessible by index but got %v.", tuple)
+	}
+
+	if tuple.String() != "(1, <nil>, c)" {
+		t.Errorf("Expected the tuple to be formatted as (1, <nil>, c) but got %v.", tuple)
+	}
+
+	key := ModelMap{tuple: ModelBool(true)}
+	if _, ok := key[NewModelTuple(ModelInt(1), nil, ModelString("c"))]; !ok {
+		t.Error("Expected an equal tuple to find the same ModelMap key.")
+	}
+}
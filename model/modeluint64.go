@@ -0,0 +1,36 @@
+package model
+
+import "strconv"
+
+// ModelUint64 is a Model for the type uint64
+type ModelUint64 uint64
+
+// Equals checks and returns 'true' if m is equal to mu
+func (mu ModelUint64) Equals(m Model) bool {
+	return mu == m
+}
+
+// Compare returns -1, 0 or 1 depending on whether mu is less than, equal
+// to or greater than the numeric model m.
+func (mu ModelUint64) Compare(m Model) int {
+	return compareNumeric(mu, m)
+}
+
+// String returns the decimal representation of mu.
+func (mu ModelUint64) String() string {
+	return strconv.FormatUint(uint64(mu), 10)
+}
+
+// Int64 converts mu into a ModelInt64.
+func (mu ModelUint64) Int64() ModelInt64 { return ModelInt64(mu) }
+
+// Uint64 converts mu into a ModelUint64.
+func (mu ModelUint64) Uint64() ModelUint64 { return mu }
+
+// Float64 converts mu into a ModelFloat64.
+func (mu ModelUint64) Float64() ModelFloat64 { return ModelFloat64(mu) }
+
+// Hash returns the hash of mu.
+func (mu ModelUint64) Hash() uint64 {
+	return uint64(newFnv64(tagUint64).addUint64(uint64(mu)))
+}
@@ -0,0 +1,53 @@
+package model
+
+import "testing"
+
+func TestModelUint64_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]ModelUint64
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Check if two values that are equal return true",
+			models: [2]ModelUint64{
+				ModelUint64(5),
+				ModelUint64(5),
+			},
+			want: true,
+		},
+		{
+			name: "Two different values that are not equal should return false",
+			models: [2]ModelUint64{
+				ModelUint64(5),
+				ModelUint64(6),
+			},
+			want: false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+	}
+}
+
+func TestModelUint64_Compare(t *testing.T) {
+	if result := ModelUint64(5).Compare(ModelUint64(5)); result != 0 {
+		t.Errorf("Equal values should compare as 0 but got %v.", result)
+	}
+
+	if result := ModelUint64(5).Compare(ModelUint64(6)); result != -1 {
+		t.Errorf("Expected a comparison of -1 but got %v.", result)
+	}
+}
+
+func TestModelUint64_String(t *testing.T) {
+	if result := ModelUint64(5).String(); result != "5" {
+		t.Errorf("Expected %q but got %q.", "5", result)
+	}
+}
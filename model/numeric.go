@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+	"math"
+)
+
+// Numeric is implemented by every Model that holds a number, and allows
+// any numeric model to be converted into another.
+//
+// Conversions follow the same rules as Go's own numeric conversions, so a
+// value that does not fit within the new type may be truncated.
+type Numeric interface {
+	Model
+	Int64() ModelInt64
+	Uint64() ModelUint64
+	Float64() ModelFloat64
+}
+
+// compareNumeric compares the numeric model n to m, which must also be
+// numeric. Integers are compared exactly, even when their types differ,
+// and any comparison involving a float is done using float64 values.
+func compareNumeric(n Numeric, m Model) int {
+	other, ok := m.(Numeric)
+	if !ok {
+		panic(incomparable(n, m))
+	}
+
+	switch {
+	case isFloat(n) || isFloat(other):
+		return compareFloat(float64(n.Float64()), float64(other.Float64()))
+	case isUnsigned(n) && isUnsigned(other):
+		return compareOrdered(n.Uint64(), other.Uint64())
+	case isUnsigned(n):
+		if other.Int64() < 0 {
+			return 1
+		}
+		return compareOrdered(n.Uint64(), other.Uint64())
+	case isUnsigned(other):
+		if n.Int64() < 0 {
+			return -1
+		}
+		return compareOrdered(n.Uint64(), other.Uint64())
+	default:
+		return compareOrdered(n.Int64(), other.Int64())
+	}
+}
+
+func isFloat(n Numeric) bool {
+	switch n.(type) {
+	case ModelInt, ModelInt64, ModelUint64, ModelByte:
+		return false
+	default:
+		return true
+	}
+}
+
+func isUnsigned(n Numeric) bool {
+	switch n.(type) {
+	case ModelUint64, ModelByte:
+		return true
+	default:
+		return false
+	}
+}
+
+type ordered interface {
+	~int | ~int64 | ~uint64 | ~uint8 | ~int32 | ~float64 | ~string
+}
+
+func compareOrdered[T ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareFloat orders NaN before every other value, so that a slice
+// containing NaN can still be sorted.
+func compareFloat(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	default:
+		return compareOrdered(a, b)
+	}
+}
+
+// incomparable builds the message used when two models cannot be compared.
+func incomparable(m1, m2 Model) string {
+	return fmt.Sprintf("model: cannot compare %T with %T", m1, m2)
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumeric_Conversions(t *testing.T) {
+	type test struct {
+		name    string
+		model   Numeric
+		int64   ModelInt64
+		uint64  ModelUint64
+		float64 ModelFloat64
+	}
+
+	table := []test{
+		{name: "ModelInt", model: ModelInt(7), int64: 7, uint64: 7, float64: 7},
+		{name: "ModelByte", model: ModelByte(200), int64: 200, uint64: 200, float64: 200},
+		{name: "ModelFloat", model: ModelFloat(2.5), int64: 2, uint64: 2, float64: 2.5},
+		{name: "ModelInt64", model: ModelInt64(-3), int64: -3, uint64: ModelUint64(math.MaxUint64 - 2), float64: -3},
+		{name: "ModelUint64", model: ModelUint64(9), int64: 9, uint64: 9, float64: 9},
+		{name: "ModelFloat64", model: ModelFloat64(9.75), int64: 9, uint64: 9, float64: 9.75},
+	}
+
+	for _, te := range table {
+		if te.model.Int64() != te.int64 || te.model.Uint64() != te.uint64 || te.model.Float64() != te.float64 {
+			t.Errorf("%v was not converted correctly.", te.name)
+		}
+	}
+}
+
+func TestNumeric_CompareAcrossTypes(t *testing.T) {
+	type test struct {
+		name string
+		m1   Numeric
+		m2   Model
+		want int
+	}
+
+	table := []test{
+		{name: "Int64 and float64 of the same value.", m1: ModelInt64(2), m2: ModelFloat64(2), want: 0},
+		{name: "Negative int64 is less than any uint64.", m1: ModelInt64(-1), m2: ModelUint64(0), want: -1},
+		{name: "Large uint64 is greater than int64.", m1: ModelUint64(math.MaxUint64), m2: ModelInt64(math.MaxInt64), want: 1},
+		{name: "Large int64 values are compared exactly.", m1: ModelInt64(1<<62 + 1), m2: ModelUint64(1 << 62), want: 1},
+		{name: "NaN is ordered before every value.", m1: ModelFloat64(math.NaN()), m2: ModelFloat64(math.Inf(-1)), want: -1},
+	}
+
+	for _, te := range table {
+		if result := compareNumeric(te.m1, te.m2); result != te.want {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, result)
+		}
+	}
+}
+
+func TestNumeric_CompareIncomparable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Comparing a numeric model with a ModelString should panic.")
+		}
+	}()
+	ModelInt64(1).Compare(ModelString("1"))
+}
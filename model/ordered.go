@@ -0,0 +1,32 @@
+package model
+
+// Ordered is an optional interface implemented by models that have a
+// natural ordering, such as the numeric and string models.
+//
+// Compare returns a negative number when the receiver is ordered before m,
+// a positive number when it is ordered after m and zero when they are equal
+// in order. Compare may panic if m cannot be compared with the receiver.
+type Ordered interface {
+	Model
+	Compare(m Model) int
+}
+
+// CompareModels compares two models using their natural ordering. A nil
+// model is ordered before every other model. CompareModels will panic if
+// m1 does not implement Ordered or cannot be compared with m2.
+func CompareModels(m1, m2 Model) int {
+	switch {
+	case m1 == nil && m2 == nil:
+		return 0
+	case m1 == nil:
+		return -1
+	case m2 == nil:
+		return 1
+	}
+
+	ordered, ok := m1.(Ordered)
+	if !ok {
+		panic(incomparable(m1, m2))
+	}
+	return ordered.Compare(m2)
+}
@@ -0,0 +1,35 @@
+package model
+
+import "testing"
+
+func TestCompareModels(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]Model
+		want   int
+	}
+
+	table := []test{
+		{name: "Smaller ModelInt should be ordered first.", models: [2]Model{ModelInt(1), ModelInt(2)}, want: -1},
+		{name: "Equal ModelBytes should compare as 0.", models: [2]Model{ModelByte(4), ModelByte(4)}, want: 0},
+		{name: "Larger ModelFloat should be ordered last.", models: [2]Model{ModelFloat(2.5), ModelFloat(1)}, want: 1},
+		{name: "Numeric models of different types compare by value.", models: [2]Model{ModelInt(3), ModelFloat64(3.5)}, want: -1},
+		{name: "Nil should be ordered before any model.", models: [2]Model{nil, ModelInt(1)}, want: -1},
+		{name: "ModelStrings should be ordered lexically.", models: [2]Model{ModelString("b"), ModelString("a")}, want: 1},
+	}
+
+	for _, te := range table {
+		if result := CompareModels(te.models[0], te.models[1]); result != te.want {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, result)
+		}
+	}
+}
+
+func TestCompareModels_NotOrdered(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Comparing models that are not Ordered should panic.")
+		}
+	}()
+	CompareModels(ModelSlice{}, ModelSlice{})
+}
@@ -0,0 +1,301 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mathew-Estafanous/funGo/optional"
+)
+
+// A path locates a model that is nested within maps and slices, using the
+// same form as the paths reported by Diff. Map keys that are plain names
+// are joined with a dot, such as "user.name", while other string keys are
+// quoted within brackets, such as `user["first name"]`. An integer within
+// brackets, such as "addresses[0]", is the index of a slice or a ModelInt
+// key of a map. The empty path is the model itself.
+//
+// Paths can be followed through a ModelMap, ModelLinkedMap, HashMap and
+// ModelSlice.
+
+// Get returns an Optional holding the model found at the path within m. The
+// Optional is empty if the path is not found, holds nil or is malformed.
+func Get(m Model, path string) optional.Optional[Model] {
+	keys, err := parsePath(path)
+	if err != nil {
+		return optional.OptionalEmpty[Model]()
+	}
+	return getPath(m, keys)
+}
+
+// Set returns a copy of m where the model at the path has been replaced by
+// value, and m itself is left unchanged. Only the maps and slices along the
+// path are copied, so the rest of the models are shared with m.
+//
+// Missing map keys that are named by the path are added, creating a
+// ModelMap for each missing level, while an index must already be within
+// its slice. An error is returned when the path is malformed or cannot be
+// followed.
+func Set(m Model, path string, value Model) (Model, error) {
+	keys, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return setPath(m, keys, 0, value)
+}
+
+// PathOf returns an operator that returns the model at the path, or nil if
+// it is not found. It can be used wherever a key extractor is taken, such
+// as by GroupingBy or Map.
+func PathOf(path string) func(Model) Model {
+	keys, err := parsePath(path)
+	return func(m Model) Model {
+		if err != nil {
+			return nil
+		}
+		return getPath(m, keys).GetOrElse(nil)
+	}
+}
+
+// PathExists returns a predicate that checks whether the path is found and
+// holds a model that is not nil.
+func PathExists(path string) func(Model) bool {
+	keys, err := parsePath(path)
+	return func(m Model) bool {
+		return err == nil && !getPath(m, keys).IsEmpty()
+	}
+}
+
+// PathEq returns a predicate that checks whether the model at the path is
+// equal to value. The value can either be a Model or a basic Go value,
+// such as a bool, string or int, which is converted into the matching
+// model. Numeric models are equal when they hold the same number, so 30
+// matches both a ModelInt and a ModelFloat64 decoded from JSON.
+func PathEq(path string, value any) func(Model) bool {
+	want := basicModel(value)
+	get := PathOf(path)
+	return func(m Model) bool {
+		got := get(m)
+		if n, ok := got.(Numeric); ok {
+			if _, ok := want.(Numeric); ok {
+				return compareNumeric(n, want) == 0
+			}
+		}
+		return ModelsEqual(got, want)
+	}
+}
+
+// basicModel converts basic Go values into their model, and uses Of for
+// every other value.
+func basicModel(v any) Model {
+	switch value := v.(type) {
+	case bool:
+		return ModelBool(value)
+	case string:
+		return ModelString(value)
+	case int:
+		return ModelInt(value)
+	case int8:
+		return ModelInt(value)
+	case int16:
+		return ModelInt(value)
+	case int32:
+		return ModelInt64(value)
+	case int64:
+		return ModelInt64(value)
+	case uint:
+		return ModelUint64(value)
+	case uint8:
+		return ModelByte(value)
+	case uint16:
+		return ModelUint64(value)
+	case uint32:
+		return ModelUint64(value)
+	case uint64:
+		return ModelUint64(value)
+	case float32:
+		return ModelFloat(value)
+	case float64:
+		return ModelFloat64(value)
+	case time.Time:
+		return ModelTime(value)
+	case time.Duration:
+		return ModelDuration(value)
+	}
+	return Of(v)
+}
+
+// parsePath splits the path into the key of each level, where names and
+// quoted keys are a ModelString and indexes are a ModelInt.
+func parsePath(path string) ([]Model, error) {
+	var keys []Model
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == '[':
+			key, n, err := parseBracket(path[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("model: invalid path %q at offset %d: %w", path, i, err)
+			}
+			keys = append(keys, key)
+			i += n + 1
+		case path[i] == '.' && i > 0, i == 0:
+			if i > 0 {
+				i++
+			}
+			end := i + strings.IndexAny(path[i:], ".[")
+			if end < i {
+				end = len(path)
+			}
+			if !isPathName(path[i:end]) {
+				return nil, fmt.Errorf("model: invalid path %q at offset %d: expected a name", path, i)
+			}
+			keys = append(keys, ModelString(path[i:end]))
+			i = end
+		default:
+			return nil, fmt.Errorf("model: invalid path %q at offset %d: unexpected %q", path, i, path[i])
+		}
+	}
+	return keys, nil
+}
+
+// parseBracket parses a quoted key or an index that follows an opening
+// bracket, and returns it along with the number of bytes read, including
+// the closing bracket.
+func parseBracket(s string) (Model, int, error) {
+	if strings.HasPrefix(s, `"`) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !strings.HasPrefix(s[len(quoted):], "]") {
+			return nil, 0, fmt.Errorf("expected ] after %s", quoted)
+		}
+		key, err := strconv.Unquote(quoted)
+		return ModelString(key), len(quoted) + 1, err
+	}
+
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return nil, 0, fmt.Errorf("missing ]")
+	}
+	index, err := strconv.Atoi(s[:end])
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid index %q", s[:end])
+	}
+	return ModelInt(index), end + 1, nil
+}
+
+// formatPath joins the keys back into a path.
+func formatPath(keys []Model) string {
+	path := ""
+	for _, k := range keys {
+		path = keyPath(path, k)
+	}
+	return path
+}
+
+func getPath(m Model, keys []Model) optional.Optional[Model] {
+	for _, k := range keys {
+		var ok bool
+		if m, ok = lookup(m, k); !ok {
+			return optional.OptionalEmpty[Model]()
+		}
+	}
+	return optional.OptionalOf(m)
+}
+
+// lookup returns the value of the key within the map, or the element at
+// the index when m is a slice.
+func lookup(m Model, key Model) (Model, bool) {
+	switch c := m.(type) {
+	case ModelMap:
+		v, ok := c[key]
+		return v, ok
+	case *ModelLinkedMap:
+		if c != nil {
+			return c.Get(key)
+		}
+	case *HashMap:
+		if c != nil {
+			return c.Get(key)
+		}
+	case ModelSlice:
+		if i, ok := key.(ModelInt); ok && i >= 0 && int(i) < len(c) {
+			return c[i], true
+		}
+	}
+	return nil, false
+}
+
+// setPath returns a copy of m with the value set at keys[depth:], where the
+// keys before depth are the path that has already been followed.
+func setPath(m Model, keys []Model, depth int, value Model) (Model, error) {
+	if depth == len(keys) {
+		return value, nil
+	}
+
+	key := keys[depth]
+	if _, ok := key.(ModelString); ok && m == nil {
+		m = ModelMap{}
+	}
+
+	switch c := m.(type) {
+	case ModelMap:
+		v, err := setPath(c[key], keys, depth+1, value)
+		if err != nil {
+			return nil, err
+		}
+		updated := make(ModelMap, len(c)+1)
+		for k, v := range c {
+			updated[k] = v
+		}
+		updated[key] = v
+		return updated, nil
+	case *ModelLinkedMap:
+		if c == nil {
+			break
+		}
+		old, _ := c.Get(key)
+		v, err := setPath(old, keys, depth+1, value)
+		if err != nil {
+			return nil, err
+		}
+		updated := NewModelLinkedMap()
+		c.ForEach(updated.Put)
+		updated.Put(key, v)
+		return updated, nil
+	case *HashMap:
+		if c == nil {
+			break
+		}
+		old, _ := c.Get(key)
+		v, err := setPath(old, keys, depth+1, value)
+		if err != nil {
+			return nil, err
+		}
+		updated := NewHashMap()
+		c.ForEach(updated.Put)
+		updated.Put(key, v)
+		return updated, nil
+	case ModelSlice:
+		i, ok := key.(ModelInt)
+		if !ok {
+			break
+		}
+		if i < 0 || int(i) >= len(c) {
+			return nil, fmt.Errorf("model: cannot set %q: index %v is out of range at %q",
+				formatPath(keys), key, formatPath(keys[:depth]))
+		}
+		v, err := setPath(c[i], keys, depth+1, value)
+		if err != nil {
+			return nil, err
+		}
+		updated := make(ModelSlice, len(c))
+		copy(updated, c)
+		updated[i] = v
+		return updated, nil
+	}
+	return nil, fmt.Errorf("model: cannot set %q: %T at %q cannot hold the key %v",
+		formatPath(keys), m, formatPath(keys[:depth]), key)
+}
@@ -0,0 +1,240 @@
+package model
+
+import "testing"
+
+func pathTestModel() Model {
+	addresses := NewModelLinkedMap()
+	addresses.Put(ModelString("city"), ModelString("Toronto"))
+	return ModelMap{
+		ModelString("user"): ModelMap{
+			ModelString("name"):   ModelString("Alex"),
+			ModelString("active"): ModelBool(true),
+			ModelString("age"):    ModelInt(30),
+			ModelString("addresses"): ModelSlice{
+				addresses,
+				ModelMap{ModelString("city"): ModelString("Ottawa")},
+			},
+			ModelString("first name"): ModelString("Alex"),
+			ModelInt(7):               ModelString("seven"),
+			ModelString("manager"):    nil,
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	type test struct {
+		name string
+		path string
+		want Model
+	}
+
+	table := []test{
+		{
+			name: "A path of names and indexes should find the nested model.",
+			path: "user.addresses[0].city",
+			want: ModelString("Toronto"),
+		},
+		{
+			name: "A quoted key should find the key that is not a plain name.",
+			path: `user["first name"]`,
+			want: ModelString("Alex"),
+		},
+		{
+			name: "An index of a map should find the ModelInt key.",
+			path: "user[7]",
+			want: ModelString("seven"),
+		},
+		{
+			name: "The empty path should find the model itself.",
+			path: "",
+			want: pathTestModel(),
+		},
+		{
+			name: "An index out of range should not be found.",
+			path: "user.addresses[2].city",
+			want: nil,
+		},
+		{
+			name: "A name within a slice should not be found.",
+			path: "user.addresses.city",
+			want: nil,
+		},
+		{
+			name: "A key holding nil should not be found.",
+			path: "user.manager",
+			want: nil,
+		},
+		{
+			name: "A malformed path should not be found.",
+			path: "user..name",
+			want: nil,
+		},
+	}
+
+	for _, te := range table {
+		got := Get(pathTestModel(), te.path).GetOrElse(nil)
+		if !ModelsEqual(got, te.want) {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, got)
+		}
+	}
+}
+
+func TestGet_DiffPaths(t *testing.T) {
+	a := pathTestModel()
+	b, err := Set(a, `user.addresses[1]["postal code"]`, ModelString("K1A"))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v.", err)
+	}
+	b, err = Set(b, "user[7]", ModelString("eight"))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v.", err)
+	}
+
+	for _, d := range Diff(a, b) {
+		if got := Get(b, d.Path).GetOrElse(nil); !ModelsEqual(got, d.Right) {
+			t.Errorf("Expected path %q to find %v but got %v.", d.Path, d.Right, got)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	type test struct {
+		name    string
+		model   Model
+		path    string
+		want    Model
+		wantErr bool
+	}
+
+	table := []test{
+		{
+			name:  "Setting a nested key should replace its value.",
+			model: ModelMap{ModelString("a"): ModelSlice{ModelMap{ModelString("b"): ModelInt(1)}}},
+			path:  "a[0].b",
+			want:  ModelMap{ModelString("a"): ModelSlice{ModelMap{ModelString("b"): ModelInt(2)}}},
+		},
+		{
+			name:  "Missing keys should be added as maps.",
+			model: ModelMap{},
+			path:  "a.b",
+			want:  ModelMap{ModelString("a"): ModelMap{ModelString("b"): ModelInt(2)}},
+		},
+		{
+			name:  "The empty path should replace the model itself.",
+			model: ModelString("old"),
+			path:  "",
+			want:  ModelInt(2),
+		},
+		{
+			name:    "An index out of range should return an error.",
+			model:   ModelSlice{ModelInt(1)},
+			path:    "[1]",
+			wantErr: true,
+		},
+		{
+			name:    "A key within a model that is not a map should return an error.",
+			model:   ModelMap{ModelString("a"): ModelInt(1)},
+			path:    "a.b",
+			wantErr: true,
+		},
+		{
+			name:    "A malformed path should return an error.",
+			model:   ModelMap{},
+			path:    `a["b]`,
+			wantErr: true,
+		},
+	}
+
+	for _, te := range table {
+		got, err := Set(te.model, te.path, ModelInt(2))
+		if (err != nil) != te.wantErr {
+			t.Errorf("%v Expected an error to be %v but got %v.", te.name, te.wantErr, err)
+			continue
+		}
+		if !te.wantErr && !ModelsEqual(got, te.want) {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, got)
+		}
+	}
+}
+
+func TestSet_Copy(t *testing.T) {
+	m := pathTestModel()
+	updated, err := Set(m, "user.addresses[0].city", ModelString("Montreal"))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v.", err)
+	}
+
+	if got := Get(m, "user.addresses[0].city").GetOrElse(nil); !ModelsEqual(got, ModelString("Toronto")) {
+		t.Errorf("Expected the original model to be unchanged but got %v.", got)
+	}
+	if got := Get(updated, "user.addresses[0].city").GetOrElse(nil); !ModelsEqual(got, ModelString("Montreal")) {
+		t.Errorf("Expected the updated model to hold Montreal but got %v.", got)
+	}
+	if _, ok := Get(updated, "user.addresses[0]").GetOrElse(nil).(*ModelLinkedMap); !ok {
+		t.Errorf("Expected the linked map to stay a linked map.")
+	}
+}
+
+func TestPathEq(t *testing.T) {
+	type test struct {
+		name  string
+		path  string
+		value any
+		model Model
+		want  bool
+	}
+
+	table := []test{
+		{
+			name:  "A Go bool should match the ModelBool.",
+			path:  "user.active",
+			value: true,
+			model: pathTestModel(),
+			want:  true,
+		},
+		{
+			name:  "A Go float should match the ModelInt holding the same number.",
+			path:  "user.age",
+			value: 30.0,
+			model: pathTestModel(),
+			want:  true,
+		},
+		{
+			name:  "A different string should not match.",
+			path:  "user.name",
+			value: "Sam",
+			model: pathTestModel(),
+			want:  false,
+		},
+		{
+			name:  "A path that is not found should only match nil.",
+			path:  "user.missing",
+			value: nil,
+			model: pathTestModel(),
+			want:  true,
+		},
+	}
+
+	for _, te := range table {
+		if got := PathEq(te.path, te.value)(te.model); got != te.want {
+			t.Errorf("%v Expected %v but got %v.", te.name, te.want, got)
+		}
+	}
+}
+
+func TestPathOf(t *testing.T) {
+	get := PathOf("user.addresses[1].city")
+	if got := get(pathTestModel()); !ModelsEqual(got, ModelString("Ottawa")) {
+		t.Errorf("Expected Ottawa but got %v.", got)
+	}
+	if got := get(ModelInt(1)); got != nil {
+		t.Errorf("Expected nil for a model without the path but got %v.", got)
+	}
+
+	if !PathExists("user.name")(pathTestModel()) {
+		t.Errorf("Expected user.name to exist.")
+	}
+	if PathExists("user.manager")(pathTestModel()) {
+		t.Errorf("Expected user.manager holding nil to not exist.")
+	}
+}
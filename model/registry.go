@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// registry holds the types of the models that were registered with
+// RegisterType, along with the name of each.
+var registry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}{
+	types: map[string]reflect.Type{},
+	names: map[reflect.Type]string{},
+}
+
+// RegisterType records the type of m under the given name, so that models
+// of that type are tagged with the name when they are encoded and decoded
+// back into the same type. Without it, a custom model is encoded as plain
+// data and decoded back into the built-in models.
+//
+// Like gob.Register, it is meant to be called during initialization, and it
+// panics if either the name or the type has already been registered. Names
+// starting with '$' are reserved for the built-in models.
+func RegisterType(name string, m Model) {
+	if m == nil {
+		panic("model: cannot register a nil model")
+	}
+	if strings.HasPrefix(name, "$") {
+		panic(fmt.Sprintf("model: type name %q is reserved", name))
+	}
+	t := reflect.TypeOf(m)
+
+	registry.Lock()
+	defer registry.Unlock()
+	if existing, ok := registry.types[name]; ok && existing != t {
+		panic(fmt.Sprintf("model: type name %q is already registered for %v", name, existing))
+	}
+	if existing, ok := registry.names[t]; ok && existing != name {
+		panic(fmt.Sprintf("model: type %v is already registered as %q", t, existing))
+	}
+	registry.types[name] = t
+	registry.names[t] = name
+}
+
+// registeredName returns the name that the type of m was registered under.
+func registeredName(m Model) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	name, ok := registry.names[reflect.TypeOf(m)]
+	return name, ok
+}
+
+// registeredType returns a pointer to a new zero value of the type that was
+// registered under the name, along with a function that returns the model
+// that the pointer holds once it has been decoded into.
+func registeredType(name string) (any, func() Model, error) {
+	registry.RLock()
+	t, ok := registry.types[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("model: type %q is not registered", name)
+	}
+
+	if t.Kind() == reflect.Pointer {
+		ptr := reflect.New(t.Elem())
+		return ptr.Interface(), func() Model { return ptr.Interface().(Model) }, nil
+	}
+	ptr := reflect.New(t)
+	return ptr.Interface(), func() Model { return ptr.Elem().Interface().(Model) }, nil
+}
@@ -0,0 +1,66 @@
+package model
+
+import "testing"
+
+type registered struct{}
+
+func (registered) Equals(m Model) bool {
+	_, ok := m.(registered)
+	return ok
+}
+
+func TestRegisterType(t *testing.T) {
+	RegisterType("model.registered", registered{})
+	RegisterType("model.registered", registered{})
+
+	if name, ok := registeredName(registered{}); !ok || name != "model.registered" {
+		t.Errorf("Expected the type to be registered as model.registered but got %v.", name)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Registering a different type under the same name should panic.")
+		}
+	}()
+	RegisterType("model.registered", ModelInt(0))
+}
+
+func TestRegisterType_Reserved(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Registering a name starting with '$' should panic.")
+		}
+	}()
+	RegisterType("$pair", registered{})
+}
+
+// counter is registered as a pointer, to test that pointer types are
+// decoded back into a pointer.
+type counter struct {
+	N int
+}
+
+func (c *counter) Equals(m Model) bool {
+	other, ok := m.(*counter)
+	return ok && c.N == other.N
+}
+
+func init() {
+	RegisterType("model.counter", &counter{})
+}
+
+func TestRegisterType_Pointer(t *testing.T) {
+	data, err := ToJSON(&counter{N: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Equals(&counter{N: 3}) {
+		t.Errorf("Expected a registered pointer type to round trip but got %v.", result)
+	}
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Value is a Model that wraps any Go value, so that values which do not
+// implement Model can still be passed through a Stream. Two Values are
+// equal if their wrapped values are equal according to reflect.DeepEqual.
+//
+// Use Of or Wrap to create a Value and Unwrap to get the value back.
+type Value struct {
+	v any
+}
+
+// Of returns v as a Model. If v already implements Model, or is nil, then it
+// is returned as is, and otherwise it is wrapped within a Value.
+func Of(v any) Model {
+	if m, ok := v.(Model); ok || v == nil {
+		return m
+	}
+	return Value{v: v}
+}
+
+// Wrap returns a Value that wraps v, even when v already implements Model.
+func Wrap(v any) Value {
+	return Value{v: v}
+}
+
+// Unwrap returns the value of m as a T. The value is unwrapped when m is a
+// Value, and otherwise m itself is used. The bool is 'false' if the value
+// is not a T.
+func Unwrap[T any](m Model) (T, bool) {
+	if wrapped, ok := m.(Value); ok {
+		v, ok := wrapped.v.(T)
+		return v, ok
+	}
+	v, ok := m.(T)
+	return v, ok
+}
+
+// ModelSliceOf converts every value of the slice into a Model using Of.
+func ModelSliceOf[T any](values []T) ModelSlice {
+	slice := make(ModelSlice, len(values))
+	for i, v := range values {
+		slice[i] = Of(v)
+	}
+	return slice
+}
+
+// Get returns the wrapped value.
+func (mv Value) Get() any {
+	return mv.v
+}
+
+// Equals checks and returns 'true' if m is a Value whose wrapped value is
+// deeply equal to the value of mv.
+func (mv Value) Equals(m Model) bool {
+	other, ok := m.(Value)
+	return ok && reflect.DeepEqual(mv.v, other.v)
+}
+
+// String returns the wrapped value formatted with its default format.
+func (mv Value) String() string {
+	return fmt.Sprint(mv.v)
+}
+
+// Hash returns the hash of mv. Values that are deeply equal produce the same
+// hash, which follows pointers to the values they point to.
+func (mv Value) Hash() uint64 {
+	return uint64(hashValue(newFnv64(tagValue), reflect.ValueOf(mv.v), 0))
+}
+
+// maxHashDepth limits how deeply hashValue follows pointers, maps, slices and
+// interfaces, so that a value which refers to itself can still be hashed.
+const maxHashDepth = 32
+
+// hashValue adds the value to the hash in the same way that reflect.DeepEqual
+// compares it. Funcs and channels only add their type.
+func hashValue(h fnv64, v reflect.Value, depth int) fnv64 {
+	if !v.IsValid() {
+		return h.addByte(tagNil)
+	}
+	h = h.addString(v.Type().String())
+	if depth > maxHashDepth {
+		return h
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return h.addByte(1)
+		}
+		return h.addByte(0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return h.addUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return h.addUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return h.addFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return h.addFloat(real(c)).addFloat(imag(c))
+	case reflect.String:
+		return h.addString(v.String())
+	case reflect.Array, reflect.Slice:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return h.addByte(tagNil)
+		}
+		h = h.addUint64(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			h = hashValue(h, v.Index(i), depth+1)
+		}
+		return h
+	case reflect.Map:
+		if v.IsNil() {
+			return h.addByte(tagNil)
+		}
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := hashValue(newFnv64(tagMapEntry), iter.Key(), depth+1)
+			sum += uint64(hashValue(entry, iter.Value(), depth+1))
+		}
+		return h.addUint64(uint64(v.Len())).addUint64(sum)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			h = hashValue(h, v.Field(i), depth+1)
+		}
+		return h
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return h.addByte(tagNil)
+		}
+		return hashValue(h, v.Elem(), depth+1)
+	default:
+		return h
+	}
+}
@@ -0,0 +1,108 @@
+package model
+
+import "testing"
+
+type employee struct {
+	Name  string
+	Tags  []string
+	Boss  *employee
+	extra map[string]int
+}
+
+func TestValue_Equals(t *testing.T) {
+	type test struct {
+		name   string
+		models [2]Model
+		want   bool
+	}
+
+	table := []test{
+		{
+			name: "Both values hold deeply equal structs, should return true.",
+			models: [2]Model{
+				Of(employee{Name: "Alex", Tags: []string{"a"}, Boss: &employee{Name: "Sam"}, extra: map[string]int{"x": 1}}),
+				Of(employee{Name: "Alex", Tags: []string{"a"}, Boss: &employee{Name: "Sam"}, extra: map[string]int{"x": 1}}),
+			},
+			want: true,
+		},
+		{
+			name: "Both values hold structs with a different nested field, should return false.",
+			models: [2]Model{
+				Of(employee{Name: "Alex", Boss: &employee{Name: "Sam"}}),
+				Of(employee{Name: "Alex", Boss: &employee{Name: "Kim"}}),
+			},
+			want: false,
+		},
+		{
+			name:   "Both values hold the same number with different types, should return false.",
+			models: [2]Model{Of(int32(1)), Of(int64(1))},
+			want:   false,
+		},
+		{
+			name:   "A value is compared with a model that is not a value, should return false.",
+			models: [2]Model{Wrap(ModelInt(1)), ModelInt(1)},
+			want:   false,
+		},
+	}
+
+	for _, te := range table {
+		model1, model2 := te.models[0], te.models[1]
+		if model1.Equals(model2) != te.want {
+			t.Error(te.name)
+		}
+		if te.want && HashModel(model1) != HashModel(model2) {
+			t.Errorf("%v Equal values should have the same hash.", te.name)
+		}
+	}
+}
+
+func TestValue_SelfReference(t *testing.T) {
+	e := &employee{Name: "Alex"}
+	e.Boss = e
+
+	if HashModel(Of(e)) != HashModel(Of(e)) {
+		t.Error("A value that refers to itself should always produce the same hash.")
+	}
+}
+
+func TestOf(t *testing.T) {
+	if m := Of(ModelInt(1)); m != ModelInt(1) {
+		t.Errorf("Of should return a model as is but got %v.", m)
+	}
+
+	if m := Of(nil); m != nil {
+		t.Errorf("Of should return nil for a nil value but got %v.", m)
+	}
+
+	if _, ok := Of("a").(Value); !ok {
+		t.Error("Of should wrap a value that is not a model.")
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	e, ok := Unwrap[employee](Of(employee{Name: "Alex"}))
+	if !ok || e.Name != "Alex" {
+		t.Errorf("Expected to unwrap the employee but got %v.", e)
+	}
+
+	if _, ok := Unwrap[string](Of(employee{})); ok {
+		t.Error("Unwrapping a value of a different type should return false.")
+	}
+
+	i, ok := Unwrap[ModelInt](ModelInt(3))
+	if !ok || i != 3 {
+		t.Errorf("Expected to unwrap a model that is not wrapped but got %v.", i)
+	}
+}
+
+func TestModelSliceOf(t *testing.T) {
+	result := ModelSliceOf([]string{"a", "b"})
+	if !result.Equals(ModelSlice{Wrap("a"), Wrap("b")}) {
+		t.Errorf("Expected a slice of wrapped strings but got %v.", result)
+	}
+
+	models := ModelSliceOf([]ModelInt{1, 2})
+	if !models.Equals(ModelSlice{ModelInt(1), ModelInt(2)}) {
+		t.Errorf("Expected models to be placed in the slice as is but got %v.", models)
+	}
+}
@@ -27,18 +27,22 @@ type Optional[T any] struct {
 // an empty optional will be returned. If a non-nil value is passed,
 // then an optional with the value will be returned.
 func OptionalOf[T any](m T) Optional[T] {
+	if any(m) == nil {
+		return OptionalEmpty[T]()
+	}
 	return Optional[T]{model: m, empty: false}
 }
 
 // OptionalEmpty very simply returns an empty Optional that contains
 // not related values.
 func OptionalEmpty[T any]() Optional[T] {
-	return Optional[T]{model: nil, empty: true}
+	var zero T
+	return Optional[T]{model: zero, empty: true}
 }
 
 // IsEmpty simply returns whether the optional contains a value or
 // not in a boolean return type.
-func (o Optional) IsEmpty() bool {
+func (o Optional[T]) IsEmpty() bool {
 	return o.empty
 }
 
@@ -48,7 +52,8 @@ func (o Optional) IsEmpty() bool {
 // be returned alongside a nil model.
 func (o Optional[T]) Get() (T, error) {
 	if o.IsEmpty() {
-		return nil, ModelNotFound
+		var zero T
+		return zero, ModelNotFound
 	}
 	return o.model, nil
 }
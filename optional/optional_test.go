@@ -14,7 +14,7 @@ func TestOptionalOf(t *testing.T) {
 		t.Errorf("Created optoinal value is %v instead of %v", opt.model, m)
 	}
 
-	emptyOpt := OptionalOf(nil)
+	emptyOpt := OptionalOf[any](nil)
 	if !emptyOpt.empty {
 		t.Errorf("Created optional is not empty as expected.")
 	}
@@ -24,7 +24,7 @@ func TestOptionalOf(t *testing.T) {
 }
 
 func TestOptionalEmpty(t *testing.T) {
-	o := OptionalEmpty()
+	o := OptionalEmpty[any]()
 	if !o.empty {
 		t.Error("The created optional is not empty, as expected.")
 	}
@@ -42,14 +42,14 @@ func TestOptional_Get(t *testing.T) {
 		t.Errorf("Received result value exected %v but received %v", m, result)
 	}
 
-	emptyOpt := Optional{model: nil, empty: true}
+	emptyOpt := Optional[any]{model: nil, empty: true}
 	if _, err := emptyOpt.Get(); err == nil {
 		t.Error("Received no error when getting from an empty optional.")
 	}
 }
 
 func TestOptional_IsEmpty(t *testing.T) {
-	emptyOpt := Optional{model: nil, empty: true}
+	emptyOpt := Optional[any]{model: nil, empty: true}
 	if !emptyOpt.IsEmpty() {
 		t.Error("Empty optional returned false for empty when it should be true.")
 	}
@@ -76,7 +76,7 @@ func TestOptional_IfNotPresent(t *testing.T) {
 	})
 
 	called := false
-	emptyOpt := Optional{model: nil, empty: true}
+	emptyOpt := Optional[any]{model: nil, empty: true}
 	emptyOpt.IfNotPresent(func() {
 		called = true
 	})
@@ -0,0 +1,123 @@
+package stream
+
+import (
+	"fmt"
+	"math"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// BloomFilter is a Model that can tell whether a model has possibly been
+// added to it, or has definitely not been added to it. False positives are
+// possible, but false negatives are not, as long as its HashFunc produces
+// the same hash for equal models. DefaultHash only does so for models that
+// implement Hasher.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+	hash   HashFunc
+}
+
+// NewBloomFilter creates and returns an empty BloomFilter that is sized to
+// hold n models with a false positive rate of fpRate. A nil hash will use
+// DefaultHash.
+func NewBloomFilter(n int, fpRate float64, hash HashFunc) *BloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+	if hash == nil {
+		hash = DefaultHash
+	}
+
+	size := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/float64(n)*math.Ln2)))
+	return &BloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+		hash:   hash,
+	}
+}
+
+// Add places the model m into the filter.
+func (bf *BloomFilter) Add(m Model) {
+	h1, h2 := bf.split(m)
+	for i := uint64(0); i < bf.hashes; i++ {
+		pos := (h1 + i*h2) % bf.size
+		bf.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// MightContain returns 'false' if the model m has definitely not been added
+// to the filter, and 'true' if it may have been added.
+func (bf *BloomFilter) MightContain(m Model) bool {
+	h1, h2 := bf.split(m)
+	for i := uint64(0); i < bf.hashes; i++ {
+		pos := (h1 + i*h2) % bf.size
+		if bf.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge combines other into bf, so that bf contains every model that was
+// added to either of them. Both must have been created with the same size.
+func (bf *BloomFilter) Merge(other *BloomFilter) error {
+	if bf.size != other.size || bf.hashes != other.hashes {
+		return ErrIncompatibleSketch
+	}
+	for i, b := range other.bits {
+		bf.bits[i] |= b
+	}
+	return nil
+}
+
+// Equals checks and returns 'true' if m is a BloomFilter with the same
+// size and bits set as bf.
+func (bf *BloomFilter) Equals(m Model) bool {
+	other, ok := m.(*BloomFilter)
+	if !ok || other == nil || bf.size != other.size || bf.hashes != other.hashes {
+		return false
+	}
+	for i, b := range bf.bits {
+		if b != other.bits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// split uses the model's hash to build the two hashes that are combined
+// to find each of the bit positions.
+func (bf *BloomFilter) split(m Model) (uint64, uint64) {
+	h := mixHash(bf.hash(m))
+	return h & math.MaxUint32, h>>32 | 1
+}
+
+// ToBloomFilter builds a collector that places every element into a
+// BloomFilter sized for n elements with a false positive rate of fpRate.
+//
+// The collector has a combiner, so it can be used with CollectParallel. The
+// combiner panics if it is given bloom filters that cannot be merged.
+func ToBloomFilter(n int, fpRate float64) Collector[Model, *BloomFilter, *BloomFilter] {
+	supplier := func() *BloomFilter { return NewBloomFilter(n, fpRate, nil) }
+
+	accumulator := func(supp *BloomFilter, model Model) *BloomFilter {
+		supp.Add(model)
+		return supp
+	}
+
+	combiner := func(b1, b2 *BloomFilter) *BloomFilter {
+		if err := b1.Merge(b2); err != nil {
+			panic(fmt.Sprintf("stream: cannot combine bloom filters: %v", err))
+		}
+		return b1
+	}
+
+	return NewCollector(supplier, accumulator, basicFinisher[*BloomFilter]).WithCombiner(combiner)
+}
@@ -0,0 +1,64 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"strings"
+	"testing"
+)
+
+func TestToBloomFilter(t *testing.T) {
+	bf := Collect(createStream(distinctInts(0, 1000)), ToBloomFilter(1000, 0.01))
+
+	for i := 0; i < 1000; i++ {
+		if !bf.MightContain(ModelInt(i)) {
+			t.Fatalf("Bloom filter reported that %v was not added.", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if bf.MightContain(ModelInt(i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 10000; rate > 0.03 {
+		t.Errorf("Expected a false positive rate close to 0.01 but got %v.", rate)
+	}
+}
+
+func TestBloomFilter_Merge(t *testing.T) {
+	first := createStream(ModelSlice{ModelInt(1), ModelInt(2)})
+	second := createStream(ModelSlice{ModelInt(3)})
+
+	bf := CollectParallel(ToBloomFilter(100, 0.01), first, second)
+	for _, m := range (ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}) {
+		if !bf.MightContain(m) {
+			t.Errorf("Merged bloom filter reported that %v was not added.", m)
+		}
+	}
+
+	if NewBloomFilter(10, 0.01, nil).Merge(NewBloomFilter(1000, 0.01, nil)) != ErrIncompatibleSketch {
+		t.Error("Merging bloom filters of different sizes should fail.")
+	}
+}
+
+func TestToBloomFilter_CombineMismatched(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Combining bloom filters of different sizes should panic.")
+		}
+	}()
+	ToBloomFilter(10, 0.01).combiner(NewBloomFilter(10, 0.01, nil), NewBloomFilter(1000, 0.01, nil))
+}
+
+func TestBloomFilter_HashFunc(t *testing.T) {
+	lower := func(m Model) uint64 {
+		return ModelString(strings.ToLower(string(m.(caseInsensitive)))).Hash()
+	}
+
+	bf := NewBloomFilter(10, 0.01, lower)
+	bf.Add(caseInsensitive("Bob"))
+	if !bf.MightContain(caseInsensitive("bob")) {
+		t.Error("Bloom filter reported that a model equal to an added model was not added.")
+	}
+}
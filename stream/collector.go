@@ -1,12 +1,28 @@
 package stream
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
 	. "github.com/Mathew-Estafanous/funGo/model"
 )
 
-// Supplier, very simply supplies the type of Model that the collector
+// Supplier, very simply supplies the container that the collector
 // will use while it is collecting all the provided elements.
-type Supplier func() Model
+type Supplier[A any] func() A
+
+// Accumulator folds a single element of type T into the container
+// of type A, returning the updated container.
+type Accumulator[A, T any] func(A, T) A
+
+// Combiner merges two containers of type A that were accumulated
+// separately into a single container.
+type Combiner[A any] func(A, A) A
+
+// Finisher transforms the accumulated container of type A into the
+// final result of type R.
+type Finisher[A, R any] func(A) R
 
 // NOTICE:
 // This struct is heavily inspired by the Java Streams Collector library and the
@@ -17,34 +33,152 @@ type Supplier func() Model
 // accumulated results that were given. This means that, it can be used to collect
 // the resulting elements within the stream into an outlined format. This means
 // collecting into Slices, Maps, or any other structure for that matter.
-type Collector struct {
-	supplier    Supplier
-	accumulator BiOperator
-	finisher    Operator
+//
+// A Collector accepts elements of type T, accumulates them into a container
+// of type A and finishes by returning a result of type R. The result type
+// is carried through to the caller of Collect, so no type assertion is needed.
+//
+// The containers of the built-in collectors, such as Groups, are exported so
+// that the type of a collector can be written out, but their contents are
+// only ever filled in and read by the collector itself.
+type Collector[T, A, R any] struct {
+	supplier    Supplier[A]
+	accumulator Accumulator[A, T]
+	combiner    Combiner[A]
+	finisher    Finisher[A, R]
 }
 
 // NewCollector is used to create a new Collector struct with the given supplier,
 // accumulator and finisher functions.
-func NewCollector(supplier Supplier, accumulator BiOperator, finisher Operator) Collector {
-	return Collector{
+func NewCollector[T, A, R any](supplier Supplier[A], accumulator Accumulator[A, T], finisher Finisher[A, R]) Collector[T, A, R] {
+	return Collector[T, A, R]{
 		supplier:    supplier,
 		accumulator: accumulator,
 		finisher:    finisher,
 	}
 }
 
+// WithCombiner returns a copy of the collector that uses the combiner to
+// merge containers that were accumulated separately. Only collectors that
+// have a combiner can be used with CollectParallel.
+func (c Collector[T, A, R]) WithCombiner(combiner Combiner[A]) Collector[T, A, R] {
+	c.combiner = combiner
+	return c
+}
+
+// finish applies the finisher to the container. If the collector has no
+// finisher, then the container itself must already be of the result type.
+func (c Collector[T, A, R]) finish(container A) R {
+	if c.finisher == nil {
+		return any(container).(R)
+	}
+	return c.finisher(container)
+}
+
+// Collect is an important terminal operator that allows flexibility in
+// in outlining how the stream should be grouped and collected. The type
+// of the result is decided by the given collector.
+//
+// Every model in the stream must be of the collector's element type T,
+// or else Collect will panic.
+func Collect[T Model, A, R any](s Stream, c Collector[T, A, R]) R {
+	defer s.Close()
+	result := c.supplier()
+
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
+		result = c.accumulator(result, elementOf[T](m))
+	}
+
+	return c.finish(result)
+}
+
+// CollectParallel collects each of the streams in its own goroutine, and then
+// uses the collector's combiner to merge the results into one. This allows
+// separate partitions of a large dataset to be collected at the same time.
+//
+// CollectParallel will panic if the collector does not have a combiner.
+func CollectParallel[T Model, A, R any](c Collector[T, A, R], streams ...Stream) R {
+	if c.combiner == nil {
+		panic("stream: CollectParallel requires a collector with a combiner")
+	}
+
+	containers := make([]A, len(streams))
+	var wg sync.WaitGroup
+	for i, s := range streams {
+		wg.Add(1)
+		go func(i int, s Stream) {
+			defer wg.Done()
+			defer s.Close()
+			container := c.supplier()
+			for m, ok := s.recv(); ok; m, ok = s.recv() {
+				container = c.accumulator(container, elementOf[T](m))
+			}
+			containers[i] = container
+		}(i, s)
+	}
+	wg.Wait()
+
+	result := c.supplier()
+	for _, container := range containers {
+		result = c.combiner(result, container)
+	}
+	return c.finish(result)
+}
+
+// elementOf converts the model into the element type T that is expected
+// by a collector. A nil model is converted to the zero value of T.
+func elementOf[T Model](m Model) T {
+	v, ok := m.(T)
+	if !ok && m != nil {
+		panic(fmt.Sprintf("stream: collector cannot accept a model of type %T", m))
+	}
+	return v
+}
+
 // ToSlice builds a collector that will accumulate all elements into a
 // ModelSlice type.
-func ToSlice() Collector {
-	supplier := func() Model { return ModelSlice{} }
+func ToSlice() Collector[Model, ModelSlice, ModelSlice] {
+	supplier := func() ModelSlice { return ModelSlice{} }
+
+	accumulator := func(supp ModelSlice, model Model) ModelSlice {
+		return append(supp, model)
+	}
 
-	accumulator := func(supp, model Model) Model {
-		return append(supp.(ModelSlice), model)
+	combiner := func(s1, s2 ModelSlice) ModelSlice {
+		return append(s1, s2...)
 	}
 
-	finisher := basicFinisher
+	return NewCollector(supplier, accumulator, basicFinisher[ModelSlice]).WithCombiner(combiner)
+}
+
+// ToSortedSlice builds a collector that will accumulate all elements into a
+// ModelSlice that is sorted using the given comparator. Models that compare
+// as equal are kept in the order they were found in the stream. A nil
+// comparator sorts the models by their natural order.
+func ToSortedSlice(cmp Comparator) Collector[Model, ModelSlice, ModelSlice] {
+	cmp = orNatural(cmp)
+	finisher := func(supp ModelSlice) ModelSlice {
+		sort.SliceStable(supp, func(i, j int) bool {
+			return cmp(supp[i], supp[j]) < 0
+		})
+		return supp
+	}
+
+	slice := ToSlice()
+	return NewCollector(slice.supplier, slice.accumulator, finisher).WithCombiner(slice.combiner)
+}
+
+// ToSet builds a collector that will accumulate all elements into a
+// ModelSet, removing any duplicate elements.
+func ToSet() Collector[Model, *ModelSet, *ModelSet] {
+	supplier := func() *ModelSet { return NewModelSet() }
+
+	accumulator := func(supp *ModelSet, model Model) *ModelSet {
+		supp.Add(model)
+		return supp
+	}
 
-	return NewCollector(supplier, accumulator, finisher)
+	return NewCollector(supplier, accumulator, basicFinisher[*ModelSet])
 }
 
 // ToMap builds a collector that will accumulate all elements into a
@@ -53,61 +187,227 @@ func ToSlice() Collector {
 // This function is useful if the key and values aren't expected to be altered
 // while being accumulated. Allowing for a simple ToMap() call instead of having
 // to specify both the key and value mappers.
-func ToMap() Collector {
-	return ToMapSpecify(basicFinisher, basicFinisher)
+//
+// The elements must be accepted by IsMapKey, or the collector panics. Use
+// ToHashMap for elements such as a ModelSlice or ModelSet.
+func ToMap() Collector[Model, ModelMap, ModelMap] {
+	return ToMapSpecify(basicFinisher[Model], basicFinisher[Model], nil)
 }
 
 // ToMapSpecify will build a collector that accumulates all elements into a
 // ModelMap by using the passed in key and value Mappers inside the created
 // accumulator.
-func ToMapSpecify(keyMapper, valueMapper Operator) Collector {
-	supplier := func() Model { return ModelMap{} }
+//
+// When two elements are mapped to the same key, the merge function is called
+// with the existing value and the new value, and its result is stored. If the
+// merge function is nil, then the newest value will replace the existing one.
+//
+// The key mapper must return keys accepted by IsMapKey, or the collector
+// panics. Use ToHashMapSpecify for keys such as a ModelSlice or ModelSet.
+func ToMapSpecify(keyMapper, valueMapper Operator, merge BiOperator) Collector[Model, ModelMap, ModelMap] {
+	supplier := func() ModelMap { return ModelMap{} }
 
-	accumulator := func(supp, model Model) Model {
+	accumulator := func(supp ModelMap, model Model) ModelMap {
 		k := keyMapper(model)
 		v := valueMapper(model)
 
-		supp.(ModelMap)[k] = v
+		checkMapKey(k, "ToHashMap or ToHashMapSpecify")
+		if existing, ok := supp[k]; ok && merge != nil {
+			v = merge(existing, v)
+		}
+		supp[k] = v
 		return supp
 	}
 
-	finisher := basicFinisher
+	return NewCollector(supplier, accumulator, basicFinisher[ModelMap])
+}
 
-	return NewCollector(supplier, accumulator, finisher)
+// checkMapKey panics with a message naming the alternative collector when k
+// cannot be used as a ModelMap key. This covers both the keys that IsMapKey
+// rejects, such as a ModelSet which a ModelMap would compare by identity,
+// and the runtime's unhashable type panic. Looking k up in a nil map hashes
+// it without allocating the map.
+func checkMapKey(k Model, alternative string) {
+	fail := func(reason any) {
+		panic(fmt.Sprintf("stream: %v (%T) cannot be used as a ModelMap key, use %v instead: %v",
+			k, k, alternative, reason))
+	}
+	if !IsMapKey(k) {
+		fail("the key would be compared by identity or is unhashable")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			fail(r)
+		}
+	}()
+	_ = ModelMap(nil)[k]
 }
 
-// GroupingBy simply groups each element according to it's
-// classifier, then placing it in the downstream collector for the value.
-func GroupingBy(classifier Operator, downstream Collector) Collector {
-	supplier := func() Model { return ModelMap{} }
+// ToLinkedMap builds a collector that will accumulate all elements into a
+// ModelLinkedMap, which keeps the keys in the order they were first found.
+func ToLinkedMap() Collector[Model, *ModelLinkedMap, *ModelLinkedMap] {
+	return ToLinkedMapSpecify(basicFinisher[Model], basicFinisher[Model], nil)
+}
+
+// ToLinkedMapSpecify works exactly like ToMapSpecify, except that the elements
+// are accumulated into a ModelLinkedMap.
+func ToLinkedMapSpecify(keyMapper, valueMapper Operator, merge BiOperator) Collector[Model, *ModelLinkedMap, *ModelLinkedMap] {
+	supplier := func() *ModelLinkedMap { return NewModelLinkedMap() }
 
-	accumulator := func(supp, model Model) Model {
+	accumulator := func(supp *ModelLinkedMap, model Model) *ModelLinkedMap {
+		k := keyMapper(model)
+		v := valueMapper(model)
+
+		if existing, ok := supp.Get(k); ok && merge != nil {
+			v = merge(existing, v)
+		}
+		supp.Put(k, v)
+		return supp
+	}
+
+	return NewCollector(supplier, accumulator, basicFinisher[*ModelLinkedMap])
+}
+
+// ToHashMap builds a collector that will accumulate all elements into a
+// HashMap, which accepts any Model as a key.
+func ToHashMap() Collector[Model, *HashMap, *HashMap] {
+	return ToHashMapSpecify(basicFinisher[Model], basicFinisher[Model], nil)
+}
+
+// ToHashMapSpecify works exactly like ToMapSpecify, except that the elements
+// are accumulated into a HashMap. Keys such as a ModelSlice, which would
+// panic within a ModelMap, can be used.
+func ToHashMapSpecify(keyMapper, valueMapper Operator, merge BiOperator) Collector[Model, *HashMap, *HashMap] {
+	supplier := func() *HashMap { return NewHashMap() }
+
+	accumulator := func(supp *HashMap, model Model) *HashMap {
+		k := keyMapper(model)
+		v := valueMapper(model)
+
+		if existing, ok := supp.Get(k); ok && merge != nil {
+			v = merge(existing, v)
+		}
+		supp.Put(k, v)
+		return supp
+	}
+
+	return NewCollector(supplier, accumulator, basicFinisher[*HashMap])
+}
+
+// Groups is the container used by the grouping collectors to hold each
+// downstream container alongside the order in which the keys were found.
+// The index maps each key to the position of its container, which allows
+// any Model to be used as a key.
+type Groups[A any] struct {
+	keys       ModelSlice
+	index      *HashMap
+	containers []A
+}
+
+// each calls f on every key and its container in the order the keys were found.
+func (g *Groups[A]) each(f func(k Model, container A)) {
+	for i, k := range g.keys {
+		f(k, g.containers[i])
+	}
+}
+
+// groupingAccumulator builds the shared accumulator used by every grouping
+// collector.
+func groupingAccumulator[A, R any](classifier Operator, downstream Collector[Model, A, R]) Accumulator[*Groups[A], Model] {
+	return func(supp *Groups[A], model Model) *Groups[A] {
 		k := classifier(model)
-		container, ok := supp.(ModelMap)[k]
+		i, ok := supp.index.Get(k)
 		if !ok {
-			container = downstream.supplier()
+			i = ModelInt(len(supp.keys))
+			supp.index.Put(k, i)
+			supp.keys = append(supp.keys, k)
+			supp.containers = append(supp.containers, downstream.supplier())
 		}
 
-		grouped := downstream.accumulator(container, model)
-		supp.(ModelMap)[k] = grouped
+		pos := int(i.(ModelInt))
+		supp.containers[pos] = downstream.accumulator(supp.containers[pos], model)
 		return supp
 	}
+}
 
-	finisher := func(m Model) Model {
-		if downstream.finisher == nil {
-			return basicFinisher(m)
-		}
+func groupingSupplier[A any]() *Groups[A] {
+	return &Groups[A]{index: NewHashMap()}
+}
 
-		s := supplier()
-		for k, v := range m.(ModelMap) {
-			s.(ModelMap)[k] = downstream.finisher(v)
-		}
-		return m
+// GroupingBy simply groups each element according to it's
+// classifier, then placing it in the downstream collector for the value.
+//
+// The downstream finisher is applied to every grouped value, which means
+// that GroupingBy collectors can be nested to group by multiple levels.
+// For example, GroupingBy(a, GroupingBy(b, Counting())).
+//
+// The keys are placed in a ModelMap, so the classifier must return keys
+// accepted by IsMapKey, or the collector panics. Use GroupingByHash for keys
+// such as a ModelSlice or ModelSet.
+func GroupingBy[A any, R Model](classifier Operator, downstream Collector[Model, A, R]) Collector[Model, *Groups[A], ModelMap] {
+	classify := func(m Model) Model {
+		k := classifier(m)
+		checkMapKey(k, "GroupingByHash")
+		return k
+	}
+
+	finisher := func(g *Groups[A]) ModelMap {
+		result := ModelMap{}
+		g.each(func(k Model, container A) {
+			result[k] = downstream.finish(container)
+		})
+		return result
+	}
+
+	return NewCollector(groupingSupplier[A], groupingAccumulator(classify, downstream), finisher)
+}
+
+// GroupingByOrdered works exactly like GroupingBy, except that the elements
+// are grouped into a ModelLinkedMap. The keys will be kept in the order in
+// which they were first classified.
+func GroupingByOrdered[A any, R Model](classifier Operator, downstream Collector[Model, A, R]) Collector[Model, *Groups[A], *ModelLinkedMap] {
+	finisher := func(g *Groups[A]) *ModelLinkedMap {
+		result := NewModelLinkedMap()
+		g.each(func(k Model, container A) {
+			result.Put(k, downstream.finish(container))
+		})
+		return result
+	}
+
+	return NewCollector(groupingSupplier[A], groupingAccumulator(classifier, downstream), finisher)
+}
+
+// GroupingByHash works exactly like GroupingBy, except that the elements are
+// grouped into a HashMap. Any Model can be used as a key, including a
+// ModelSlice or ModelMap.
+func GroupingByHash[A any, R Model](classifier Operator, downstream Collector[Model, A, R]) Collector[Model, *Groups[A], *HashMap] {
+	finisher := func(g *Groups[A]) *HashMap {
+		result := NewHashMap()
+		g.each(func(k Model, container A) {
+			result.Put(k, downstream.finish(container))
+		})
+		return result
+	}
+
+	return NewCollector(groupingSupplier[A], groupingAccumulator(classifier, downstream), finisher)
+}
+
+// Counting builds a collector that counts the number of elements that
+// have been accumulated and returns the total as a ModelInt.
+func Counting() Collector[Model, ModelInt, ModelInt] {
+	supplier := func() ModelInt { return ModelInt(0) }
+
+	accumulator := func(supp ModelInt, _ Model) ModelInt {
+		return supp + 1
+	}
+
+	combiner := func(c1, c2 ModelInt) ModelInt {
+		return c1 + c2
 	}
 
-	return NewCollector(supplier, accumulator, finisher)
+	return NewCollector(supplier, accumulator, basicFinisher[ModelInt]).WithCombiner(combiner)
 }
 
-func basicFinisher(m Model) Model {
-	return m
+func basicFinisher[A any](a A) A {
+	return a
 }
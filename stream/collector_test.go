@@ -2,24 +2,26 @@ package stream
 
 import (
 	. "github.com/Mathew-Estafanous/funGo/model"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
 func TestNewCollector(t *testing.T) {
-	supplier := func() Model {
+	supplier := func() ModelSlice {
 		return ModelSlice{}
 	}
 
-	accumulator := func(m1, m2 Model) Model {
-		return append(m1.(ModelSlice), m2)
+	accumulator := func(m1 ModelSlice, m2 Model) ModelSlice {
+		return append(m1, m2)
 	}
 
-	finisher := func(m Model) Model {
-		return m
+	finisher := func(m ModelSlice) ModelInt {
+		return ModelInt(len(m))
 	}
 
 	collector := NewCollector(supplier, accumulator, finisher)
-	if _, ok := collector.supplier().(ModelSlice); !ok {
+	if collector.supplier() == nil {
 		t.Error("NewCollector did not use the supplier that was passed in.")
 	}
 
@@ -28,7 +30,7 @@ func TestNewCollector(t *testing.T) {
 		t.Error("NewCollector did not use the accumulator that was passed in.")
 	}
 
-	collectorFinisher := collector.finisher(ModelInt(1))
+	collectorFinisher := collector.finisher(ModelSlice{ModelInt(1)})
 	if !collectorFinisher.Equals(ModelInt(1)) {
 		t.Error("NewCollector did not use the finisher that was passed in.")
 	}
@@ -38,7 +40,7 @@ func TestToSlice(t *testing.T) {
 	collector := ToSlice()
 
 	supplierResult := collector.supplier()
-	if _, ok := supplierResult.(ModelSlice); !ok {
+	if supplierResult == nil || len(supplierResult) != 0 {
 		t.Error("ToSlice supplier does not return a valid ModelSlice type.")
 	}
 
@@ -47,31 +49,30 @@ func TestToSlice(t *testing.T) {
 		t.Error("ToSlice accumulator should properly append Model into the given slice.")
 	}
 
-	finisherResult := collector.finisher(ModelInt(1))
-	if !finisherResult.Equals(ModelInt(1)) {
+	finisherResult := collector.finisher(ModelSlice{ModelInt(1)})
+	if !finisherResult.Equals(ModelSlice{ModelInt(1)}) {
 		t.Error("Finished for ToSlice should return the exact same model.")
 	}
 }
 
 func TestGroupingBy(t *testing.T) {
-	mockCollector := Collector{
-		supplier:    func() Model { return ModelSlice{} },
-		accumulator: func(m1, m2 Model) Model { return append(m1.(ModelSlice), m2) },
-		finisher:    func(m Model) Model { return m },
+	mockCollector := Collector[Model, ModelSlice, ModelSlice]{
+		supplier:    func() ModelSlice { return ModelSlice{} },
+		accumulator: func(m1 ModelSlice, m2 Model) ModelSlice { return append(m1, m2) },
+		finisher:    func(m ModelSlice) ModelSlice { return m },
 	}
 
 	groupCollector := GroupingBy(func(m Model) Model {
 		return m.(ModelInt)
 	}, mockCollector)
 
-	supplierResult := groupCollector.supplier()
-	if !supplierResult.Equals(ModelMap{}) {
-		t.Error("GroupingBy supplier did not return a valid ModelMap type.")
+	accumulatorResult := groupCollector.supplier()
+	if len(accumulatorResult.keys) != 0 {
+		t.Error("GroupingBy supplier did not return an empty container.")
 	}
 
-	accumulatorResult := ModelMap{}
 	for i := 0; i < 2; i++ {
-		accumulatorResult = groupCollector.accumulator(accumulatorResult, ModelInt(i)).(ModelMap)
+		accumulatorResult = groupCollector.accumulator(accumulatorResult, ModelInt(i))
 	}
 
 	expectedMap := ModelMap{
@@ -79,8 +80,8 @@ func TestGroupingBy(t *testing.T) {
 		ModelInt(1): ModelSlice{ModelInt(1)},
 	}
 
-	if !accumulatorResult.Equals(expectedMap) {
-		t.Error("GroupingBy accumulator did not properly create the correct ModelMap")
+	if !accumulatorResult.keys.Equals(ModelSlice{ModelInt(0), ModelInt(1)}) {
+		t.Error("GroupingBy accumulator did not properly group the models")
 	}
 
 	finisherResults := groupCollector.finisher(accumulatorResult)
@@ -91,7 +92,7 @@ func TestGroupingBy(t *testing.T) {
 
 func TestToMapSpecify(t *testing.T) {
 	basicOp := func(m Model) Model { return m.(ModelInt) + 1 }
-	mapCollector := ToMapSpecify(basicOp, basicOp)
+	mapCollector := ToMapSpecify(basicOp, basicOp, nil)
 
 	supplierResult := mapCollector.supplier()
 	if !supplierResult.Equals(ModelMap{}) {
@@ -99,7 +100,7 @@ func TestToMapSpecify(t *testing.T) {
 	}
 
 	accumulatorResult := ModelMap{}
-	accumulatorResult = mapCollector.accumulator(accumulatorResult, ModelInt(0)).(ModelMap)
+	accumulatorResult = mapCollector.accumulator(accumulatorResult, ModelInt(0))
 
 	expectedMap := ModelMap{
 		ModelInt(1): ModelInt(1),
@@ -128,7 +129,7 @@ func TestToMap(t *testing.T) {
 	}
 
 	accumulatorResult := ModelMap{}
-	accumulatorResult = mapCollector.accumulator(accumulatorResult, ModelInt(0)).(ModelMap)
+	accumulatorResult = mapCollector.accumulator(accumulatorResult, ModelInt(0))
 	if !accumulatorResult.Equals(expectedMap) {
 		t.Error("ToMap accumulator did not used the basic function when accumulating.")
 	}
@@ -138,3 +139,294 @@ func TestToMap(t *testing.T) {
 		t.Error("ToMap finished did not properly finalize the type.")
 	}
 }
+
+func TestGroupingBy_Nested(t *testing.T) {
+	parity := func(m Model) Model { return m.(ModelInt) % 2 }
+	size := func(m Model) Model {
+		if m.(ModelInt) > 2 {
+			return ModelByte('L')
+		}
+		return ModelByte('S')
+	}
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3), ModelInt(4), ModelInt(5)}),
+		GroupingBy(parity, GroupingBy(size, Counting())))
+
+	expectedMap := ModelMap{
+		ModelInt(0): ModelMap{ModelByte('S'): ModelInt(1), ModelByte('L'): ModelInt(1)},
+		ModelInt(1): ModelMap{ModelByte('S'): ModelInt(1), ModelByte('L'): ModelInt(2)},
+	}
+
+	if !result.Equals(expectedMap) {
+		t.Errorf("Nested GroupingBy expected %v but got %v.", expectedMap, result)
+	}
+}
+
+func TestGroupingBy_DownstreamFinisher(t *testing.T) {
+	lengthCollector := NewCollector(
+		func() ModelSlice { return ModelSlice{} },
+		func(m1 ModelSlice, m2 Model) ModelSlice { return append(m1, m2) },
+		func(m ModelSlice) ModelInt { return ModelInt(len(m)) },
+	)
+	identity := func(m Model) Model { return m }
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(1), ModelInt(2)}),
+		GroupingBy(identity, GroupingBy(identity, lengthCollector)))
+
+	expectedMap := ModelMap{
+		ModelInt(1): ModelMap{ModelInt(1): ModelInt(2)},
+		ModelInt(2): ModelMap{ModelInt(2): ModelInt(1)},
+	}
+
+	if !result.Equals(expectedMap) {
+		t.Errorf("GroupingBy did not apply the downstream finishers, expected %v but got %v.", expectedMap, result)
+	}
+}
+
+func TestGroupingByOrdered(t *testing.T) {
+	identity := func(m Model) Model { return m }
+
+	result := Collect(createStream(ModelSlice{ModelInt(3), ModelInt(1), ModelInt(3), ModelInt(2)}),
+		GroupingByOrdered(identity, Counting()))
+
+	expected := NewModelLinkedMap()
+	expected.Put(ModelInt(3), ModelInt(2))
+	expected.Put(ModelInt(1), ModelInt(1))
+	expected.Put(ModelInt(2), ModelInt(1))
+
+	if !result.Equals(expected) {
+		t.Errorf("GroupingByOrdered expected keys %v but got %v.", expected.Keys(), result.Keys())
+	}
+}
+
+func TestGroupingBy_TupleKey(t *testing.T) {
+	parity := func(m Model) Model {
+		i := m.(ModelInt)
+		return NewModelTuple(i%2, ModelBool(i > 2))
+	}
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3), ModelInt(5)}),
+		GroupingBy(parity, Counting()))
+
+	expectedMap := ModelMap{
+		NewModelTuple(ModelInt(1), ModelBool(false)): ModelInt(1),
+		NewModelTuple(ModelInt(0), ModelBool(false)): ModelInt(1),
+		NewModelTuple(ModelInt(1), ModelBool(true)):  ModelInt(2),
+	}
+
+	if !result.Equals(expectedMap) {
+		t.Errorf("GroupingBy with tuple keys expected %v but got %v.", expectedMap, result)
+	}
+}
+
+func TestGroupingByHash(t *testing.T) {
+	pair := func(m Model) Model {
+		i := m.(ModelInt)
+		return ModelSlice{i % 2, i % 3}
+	}
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(7), ModelInt(2), ModelInt(4)}),
+		GroupingByHash(pair, Counting()))
+
+	expected := NewHashMap()
+	expected.Put(ModelSlice{ModelInt(1), ModelInt(1)}, ModelInt(2))
+	expected.Put(ModelSlice{ModelInt(0), ModelInt(2)}, ModelInt(1))
+	expected.Put(ModelSlice{ModelInt(0), ModelInt(1)}, ModelInt(1))
+
+	if !result.Equals(expected) {
+		t.Errorf("GroupingByHash expected keys %v but got %v.", expected.Keys(), result.Keys())
+	}
+}
+
+func TestGroupingByHash_SetKey(t *testing.T) {
+	parity := func(m Model) Model { return NewModelSet(m.(ModelInt) % 2) }
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}), GroupingByHash(parity, Counting()))
+
+	if count, _ := result.Get(NewModelSet(ModelInt(1))); count != ModelInt(2) {
+		t.Errorf("GroupingByHash expected 2 odd models but got %v.", count)
+	}
+}
+
+func TestToHashMapSpecify(t *testing.T) {
+	key := func(m Model) Model { return ModelSlice{m.(ModelInt) % 2} }
+	identity := func(m Model) Model { return m }
+	sum := func(m1, m2 Model) Model { return m1.(ModelInt) + m2.(ModelInt) }
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3), ModelInt(4)}),
+		ToHashMapSpecify(key, identity, sum))
+
+	if v, _ := result.Get(ModelSlice{ModelInt(0)}); v != ModelInt(6) {
+		t.Errorf("ToHashMapSpecify expected even numbers to sum to 6 but got %v.", v)
+	}
+	if v, _ := result.Get(ModelSlice{ModelInt(1)}); v != ModelInt(4) {
+		t.Errorf("ToHashMapSpecify expected odd numbers to sum to 4 but got %v.", v)
+	}
+}
+
+func TestCounting(t *testing.T) {
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}), Counting())
+	if result != ModelInt(3) {
+		t.Errorf("Counting expected 3 but got %v.", result)
+	}
+}
+
+func TestCollect_Typed(t *testing.T) {
+	doubled := NewCollector(
+		func() ModelInt { return 0 },
+		func(sum ModelInt, m ModelInt) ModelInt { return sum + m*2 },
+		func(sum ModelInt) ModelInt { return sum },
+	)
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2)}), doubled)
+	if result != ModelInt(6) {
+		t.Errorf("Collect with a typed collector expected 6 but got %v.", result)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Collect did not panic when given a model of the wrong type.")
+		}
+	}()
+	Collect(createStream(ModelSlice{ModelByte(1)}), doubled)
+}
+
+func TestToMapSpecify_Merge(t *testing.T) {
+	parity := func(m Model) Model { return m.(ModelInt) % 2 }
+	identity := func(m Model) Model { return m }
+	sum := func(m1, m2 Model) Model { return m1.(ModelInt) + m2.(ModelInt) }
+
+	result := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3), ModelInt(4)}),
+		ToMapSpecify(parity, identity, sum))
+
+	expectedMap := ModelMap{
+		ModelInt(0): ModelInt(6),
+		ModelInt(1): ModelInt(4),
+	}
+
+	if !result.Equals(expectedMap) {
+		t.Errorf("ToMapSpecify did not merge duplicate keys, expected %v but got %v.", expectedMap, result)
+	}
+}
+
+func TestToSet(t *testing.T) {
+	result := Collect(createStream(ModelSlice{ModelInt(2), ModelInt(1), ModelInt(2)}), ToSet())
+
+	if !result.Equals(NewModelSet(ModelInt(1), ModelInt(2))) {
+		t.Errorf("ToSet did not remove duplicates, got %v.", result.Slice())
+	}
+}
+
+func TestToSortedSlice(t *testing.T) {
+	byValue := func(m1, m2 Model) int { return int(m1.(ModelInt) - m2.(ModelInt)) }
+
+	result := Collect(createStream(ModelSlice{ModelInt(3), ModelInt(1), ModelInt(2)}), ToSortedSlice(byValue))
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}) {
+		t.Errorf("ToSortedSlice expected a sorted slice but got %v.", result)
+	}
+}
+
+func TestToLinkedMap(t *testing.T) {
+	result := Collect(createStream(ModelSlice{ModelInt(3), ModelInt(1), ModelInt(2)}), ToLinkedMap())
+
+	if !result.Keys().Equals(ModelSlice{ModelInt(3), ModelInt(1), ModelInt(2)}) {
+		t.Errorf("ToLinkedMap expected keys in insertion order but got %v.", result.Keys())
+	}
+}
+
+func TestCollectParallel(t *testing.T) {
+	first := createStream(ModelSlice{ModelInt(1), ModelInt(2)})
+	second := createStream(ModelSlice{ModelInt(3)})
+
+	result := CollectParallel(Counting(), first, second)
+	if result != ModelInt(3) {
+		t.Errorf("CollectParallel expected a combined count of 3 but got %v.", result)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("CollectParallel did not panic when the collector has no combiner.")
+		}
+	}()
+	CollectParallel(ToSet(), createStream(ModelSlice{}))
+}
+
+func TestToSortedSlice_NaturalOrder(t *testing.T) {
+	result := Collect(createStream(ModelSlice{ModelFloat64(2.5), ModelInt(1), ModelInt64(2)}), ToSortedSlice(nil))
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt64(2), ModelFloat64(2.5)}) {
+		t.Errorf("ToSortedSlice with no comparator expected natural order but got %v.", result)
+	}
+}
+
+func TestGroupingBy_NamedType(t *testing.T) {
+	parity := func(m Model) Model { return m.(ModelInt) % 2 }
+
+	var byParity Collector[Model, *Groups[ModelInt], ModelMap] = GroupingBy(parity, Counting())
+	var topTwo Collector[Model, *BoundedHeap, ModelSlice] = TopK(2, nil)
+	var sample Collector[Model, *Reservoir, ModelSlice] = SampleN(2, rand.New(rand.NewSource(1)))
+
+	models := ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}
+	if result := Collect(createStream(models), byParity); !result.Equals(ModelMap{ModelInt(0): ModelInt(1), ModelInt(1): ModelInt(2)}) {
+		t.Errorf("GroupingBy expected the count of each parity but got %v.", result)
+	}
+	if result := Collect(createStream(models), topTwo); !result.Equals(ModelSlice{ModelInt(3), ModelInt(2)}) {
+		t.Errorf("TopK expected [3 2] but got %v.", result)
+	}
+	if result := Collect(createStream(models), sample); len(result) != 2 {
+		t.Errorf("SampleN expected 2 models but got %v.", result)
+	}
+}
+
+func TestCollector_UnhashableKey(t *testing.T) {
+	type test struct {
+		name        string
+		collect     func()
+		alternative string
+	}
+
+	slices := ModelSlice{ModelSlice{ModelInt(1)}, ModelSlice{ModelInt(2)}}
+	identity := func(m Model) Model { return m }
+	pairOf := func(m Model) Model { return NewModelPair(m, m) }
+	setOf := func(m Model) Model { return NewModelSet(m) }
+	table := []test{
+		{
+			name:        "ToMap with slice keys should point to ToHashMap.",
+			collect:     func() { Collect(createStream(slices), ToMap()) },
+			alternative: "ToHashMap",
+		},
+		{
+			name:        "ToMapSpecify with a pair holding a slice should point to ToHashMapSpecify.",
+			collect:     func() { Collect(createStream(slices), ToMapSpecify(pairOf, identity, nil)) },
+			alternative: "ToHashMapSpecify",
+		},
+		{
+			name:        "GroupingBy with slice keys should point to GroupingByHash.",
+			collect:     func() { Collect(createStream(slices), GroupingBy(identity, Counting())) },
+			alternative: "GroupingByHash",
+		},
+		{
+			name:        "GroupingBy with set keys should point to GroupingByHash.",
+			collect:     func() { Collect(createStream(slices), GroupingBy(setOf, Counting())) },
+			alternative: "GroupingByHash",
+		},
+		{
+			name:        "ToMap with set keys should point to ToHashMap.",
+			collect:     func() { Collect(createStream(ModelSlice{NewModelSet(ModelInt(1))}), ToMap()) },
+			alternative: "ToHashMap",
+		},
+	}
+
+	for _, te := range table {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if !strings.Contains(msg, te.alternative) {
+					t.Errorf("%v Expected a panic naming %v but got %q.", te.name, te.alternative, msg)
+				}
+			}()
+			te.collect()
+		}()
+	}
+}
@@ -0,0 +1,214 @@
+package stream
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// CSVOptions outlines how CSV data is read by FromCSV and written by ToCSV.
+// The zero value reads comma separated records keyed by the header row and
+// infers the type of every field.
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+
+	// Comment, if not 0, marks lines beginning with it as comments that
+	// are skipped when reading.
+	Comment rune
+
+	// Positional reads every row, including the first, as a ModelSlice
+	// instead of treating the first row as a header.
+	Positional bool
+
+	// DisableInference keeps every field as a ModelString instead of
+	// converting numeric fields into a ModelInt or ModelFloat64.
+	DisableInference bool
+}
+
+func (o CSVOptions) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+// FromCSV creates a Stream of the records read from r. Unless the options
+// ask for positional records, the first row is used as the header and every
+// following record is a ModelMap from the ModelString header to the field.
+//
+// Fields that hold an integer become a ModelInt, fields that hold a decimal
+// number become a ModelFloat64 and every other field is a ModelString.
+// Numbers with leading zeros, such as zip codes, integers too large for an
+// int, such as long IDs, numbers with a leading "+" and words such as "NaN"
+// or "Inf" are kept as strings, so that they are written back unchanged.
+// Any malformed record stops the stream and is reported by Err.
+func FromCSV(r io.Reader, opts CSVOptions) Stream {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.comma()
+	reader.Comment = opts.Comment
+	reader.ReuseRecord = true
+
+	return newSource(func(send func(Model) bool) error {
+		var header ModelSlice
+		if !opts.Positional {
+			row, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			for _, name := range row {
+				header = append(header, ModelString(name))
+			}
+		}
+
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if !send(csvRecord(row, header, opts)) {
+				return nil
+			}
+		}
+	})
+}
+
+// csvRecord converts the row into a ModelMap keyed by the header, or into
+// a ModelSlice when there is no header.
+func csvRecord(row []string, header ModelSlice, opts CSVOptions) Model {
+	if header == nil {
+		record := make(ModelSlice, len(row))
+		for i, field := range row {
+			record[i] = csvField(field, opts)
+		}
+		return record
+	}
+
+	record := ModelMap{}
+	for i, field := range row {
+		record[header[i]] = csvField(field, opts)
+	}
+	return record
+}
+
+func csvField(field string, opts CSVOptions) Model {
+	if opts.DisableInference {
+		return ModelString(field)
+	}
+	if !isCSVNumber(field) {
+		return ModelString(field)
+	}
+	if !strings.ContainsAny(field, ".eE") {
+		if i, err := strconv.Atoi(field); err == nil {
+			return ModelInt(i)
+		}
+		return ModelString(field)
+	}
+	if f, err := strconv.ParseFloat(field, 64); err == nil {
+		return ModelFloat64(f)
+	}
+	return ModelString(field)
+}
+
+// isCSVNumber returns 'true' if the field is written as a plain decimal
+// number that is safe to convert, without a leading "+" or leading zeros
+// that would be lost and without words such as "Inf" that ParseFloat also
+// accepts.
+func isCSVNumber(field string) bool {
+	digits := strings.TrimPrefix(field, "-")
+	if strings.HasPrefix(digits, "+") {
+		return false
+	}
+	if len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9' {
+		return false
+	}
+	return digits != "" && strings.Trim(digits, "0123456789.eE+-") == ""
+}
+
+// ToCSV is a terminal operation that writes every model in the stream to w
+// as a CSV record. Fields are quoted whenever it is needed.
+//
+// When columns are given, they are written as the header row and decide the
+// order of the fields. A ModelMap or ModelLinkedMap record is written using the
+// value of every column, and a missing value is written as an empty field. A
+// ModelSlice record is written in its own order, so columns can be left nil.
+//
+// The first error found, either while writing or by the stream, is returned.
+func (s Stream) ToCSV(w io.Writer, columns []string, opts CSVOptions) error {
+	defer s.Close()
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.comma()
+
+	if len(columns) > 0 {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, 0, len(columns))
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
+		var err error
+		if row, err = csvRow(row[:0], m, columns); err != nil {
+			return err
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return s.Err()
+}
+
+// csvRow appends the fields of the model to row in the order of the columns.
+func csvRow(row []string, m Model, columns []string) ([]string, error) {
+	switch record := m.(type) {
+	case ModelSlice:
+		for _, v := range record {
+			row = append(row, csvString(v))
+		}
+	case ModelMap:
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("stream: columns are required to write a %T as a CSV record", m)
+		}
+		for _, c := range columns {
+			row = append(row, csvString(record[ModelString(c)]))
+		}
+	case *ModelLinkedMap:
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("stream: columns are required to write a %T as a CSV record", m)
+		}
+		for _, c := range columns {
+			v, _ := record.Get(ModelString(c))
+			row = append(row, csvString(v))
+		}
+	default:
+		return nil, fmt.Errorf("stream: cannot write a %T as a CSV record", m)
+	}
+	return row, nil
+}
+
+func csvString(m Model) string {
+	switch v := m.(type) {
+	case nil:
+		return ""
+	case ModelString:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,124 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"strings"
+	"testing"
+)
+
+func TestFromCSV(t *testing.T) {
+	input := "name,age,salary\nAlex,31,45500.5\n\"Smith, Josh\",27,39000\n"
+	result := Collect(FromCSV(strings.NewReader(input), CSVOptions{}), ToSlice())
+
+	want := ModelSlice{
+		ModelMap{ModelString("name"): ModelString("Alex"), ModelString("age"): ModelInt(31), ModelString("salary"): ModelFloat64(45500.5)},
+		ModelMap{ModelString("name"): ModelString("Smith, Josh"), ModelString("age"): ModelInt(27), ModelString("salary"): ModelInt(39000)},
+	}
+
+	if !result.Equals(want) {
+		t.Errorf("FromCSV expected %v but got %v.", want, result)
+	}
+}
+
+func TestFromCSV_Inference(t *testing.T) {
+	type test struct {
+		name  string
+		field string
+		want  Model
+	}
+
+	table := []test{
+		{name: "An integer should become a ModelInt.", field: "-42", want: ModelInt(-42)},
+		{name: "Zero should become a ModelInt.", field: "0", want: ModelInt(0)},
+		{name: "A decimal should become a ModelFloat64 at full precision.", field: "0.1", want: ModelFloat64(0.1)},
+		{name: "An exponent should become a ModelFloat64.", field: "1.5e3", want: ModelFloat64(1500)},
+		{name: "A zip code with a leading zero should stay a string.", field: "02134", want: ModelString("02134")},
+		{name: "A negative number with a leading zero should stay a string.", field: "-007", want: ModelString("-007")},
+		{name: "NaN should stay a string.", field: "nan", want: ModelString("nan")},
+		{name: "Infinity should stay a string.", field: "Infinity", want: ModelString("Infinity")},
+		{name: "Inf should stay a string.", field: "-inf", want: ModelString("-inf")},
+		{name: "A hexadecimal number should stay a string.", field: "0x1p4", want: ModelString("0x1p4")},
+		{name: "An integer too large for an int should stay a string.", field: "12345678901234567890", want: ModelString("12345678901234567890")},
+		{name: "A number with a leading plus should stay a string.", field: "+5", want: ModelString("+5")},
+		{name: "A decimal with a leading plus should stay a string.", field: "+5.5", want: ModelString("+5.5")},
+		{name: "An exponent with a plus sign should become a ModelFloat64.", field: "1e+3", want: ModelFloat64(1000)},
+	}
+
+	for _, te := range table {
+		if got := csvField(te.field, CSVOptions{}); !ModelsEqual(got, te.want) {
+			t.Errorf("%v Expected %v (%T) but got %v (%T).", te.name, te.want, te.want, got, got)
+		}
+	}
+}
+
+func TestFromCSV_Positional(t *testing.T) {
+	input := "a;1\nb;2\n"
+	opts := CSVOptions{Comma: ';', Positional: true, DisableInference: true}
+	result := Collect(FromCSV(strings.NewReader(input), opts), ToSlice())
+
+	want := ModelSlice{
+		ModelSlice{ModelString("a"), ModelString("1")},
+		ModelSlice{ModelString("b"), ModelString("2")},
+	}
+
+	if !result.Equals(want) {
+		t.Errorf("FromCSV with positional records expected %v but got %v.", want, result)
+	}
+}
+
+func TestFromCSV_Malformed(t *testing.T) {
+	input := "name,age\nAlex,31\nJosh\n"
+	s := FromCSV(strings.NewReader(input), CSVOptions{})
+	count := s.Count()
+
+	if count != 1 {
+		t.Errorf("Expected the single record before the malformed one but got %v.", count)
+	}
+
+	if !errors.Is(s.Err(), csv.ErrFieldCount) {
+		t.Errorf("Expected a field count error to be reported but got %v.", s.Err())
+	}
+}
+
+func TestStream_ToCSV(t *testing.T) {
+	records := ModelSlice{
+		ModelMap{ModelString("name"): ModelString("Alex"), ModelString("age"): ModelInt(31)},
+		ModelMap{ModelString("name"): ModelString("Smith, \"Josh\"")},
+	}
+
+	var buf bytes.Buffer
+	err := NewStreamFromSlice(records).ToCSV(&buf, []string{"name", "age"}, CSVOptions{})
+	if err != nil {
+		t.Fatalf("ToCSV returned an unexpected error %v.", err)
+	}
+
+	want := "name,age\nAlex,31\n\"Smith, \"\"Josh\"\"\",\n"
+	if buf.String() != want {
+		t.Errorf("ToCSV expected %q but got %q.", want, buf.String())
+	}
+}
+
+func TestStream_ToCSV_RoundTrip(t *testing.T) {
+	input := "a\tb\n1\tx y\n"
+	opts := CSVOptions{Comma: '\t', Positional: true}
+
+	var buf bytes.Buffer
+	if err := FromCSV(strings.NewReader(input), opts).ToCSV(&buf, nil, opts); err != nil {
+		t.Fatalf("ToCSV returned an unexpected error %v.", err)
+	}
+
+	if buf.String() != input {
+		t.Errorf("Expected the CSV to be written back as %q but got %q.", input, buf.String())
+	}
+}
+
+func TestStream_ToCSV_Unsupported(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewStreamFromSlice(ModelSlice{ModelInt(1)}).ToCSV(&buf, nil, CSVOptions{})
+	if err == nil {
+		t.Error("ToCSV should return an error for models that are not records.")
+	}
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// FileEntry is a Model that describes a single file or directory that was
+// found by FromDir.
+type FileEntry struct {
+	Path    string
+	Size    int64
+	Mode    fs.FileMode
+	ModTime time.Time
+}
+
+// IsDir returns 'true' if the entry is a directory.
+func (fe FileEntry) IsDir() bool {
+	return fe.Mode.IsDir()
+}
+
+// Equals checks and returns 'true' if m is a FileEntry that describes the
+// same path, size, mode and modification time.
+func (fe FileEntry) Equals(m Model) bool {
+	other, ok := m.(FileEntry)
+	if !ok {
+		return false
+	}
+	return fe.Path == other.Path && fe.Size == other.Size &&
+		fe.Mode == other.Mode && fe.ModTime.Equal(other.ModTime)
+}
+
+// errStopWalk is used to stop walking once the stream has been cancelled.
+var errStopWalk = errors.New("stream: walk stopped")
+
+// FromDir creates a Stream that contains a FileEntry for every file and
+// directory within root, including root itself, in lexical order. The walk
+// is done lazily using fs.WalkDir, and stops as soon as the stream is
+// cancelled.
+//
+// When skipDir is not nil, it is called with every directory that is found.
+// If it returns 'true', then the directory and everything within it is left
+// out of the stream. Any error found while walking stops the stream and is
+// reported by Err.
+func FromDir(fsys fs.FS, root string, skipDir Predicate) Stream {
+	return newSource(func(send func(Model) bool) error {
+		err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			entry := FileEntry{
+				Path:    path,
+				Size:    info.Size(),
+				Mode:    info.Mode(),
+				ModTime: info.ModTime(),
+			}
+			if d.IsDir() && skipDir != nil && skipDir(entry) {
+				return fs.SkipDir
+			}
+
+			if !send(entry) {
+				return errStopWalk
+			}
+			return nil
+		})
+
+		if err == errStopWalk {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"errors"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func testFS() fstest.MapFS {
+	modTime := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	return fstest.MapFS{
+		"logs/app.log":        {Data: []byte("started\n"), ModTime: modTime},
+		"logs/old/app.log":    {Data: []byte("old"), ModTime: modTime},
+		"src/main.go":         {Data: []byte("package main\n"), ModTime: modTime},
+		"src/vendor/x/x.go":   {Data: []byte("package x\n"), ModTime: modTime},
+		"README.md":           {Data: []byte("# readme"), ModTime: modTime},
+		"src/testdata/big.go": {Data: make([]byte, 2048), ModTime: modTime},
+	}
+}
+
+func entryPaths(s Stream) ModelSlice {
+	return Collect(s.Map(func(m Model) Model {
+		return ModelString(m.(FileEntry).Path)
+	}), ToSlice())
+}
+
+func TestFromDir(t *testing.T) {
+	isFile := func(m Model) bool { return !m.(FileEntry).IsDir() }
+	result := entryPaths(FromDir(testFS(), ".", nil).Filter(isFile))
+
+	want := ModelSlice{
+		ModelString("README.md"),
+		ModelString("logs/app.log"),
+		ModelString("logs/old/app.log"),
+		ModelString("src/main.go"),
+		ModelString("src/testdata/big.go"),
+		ModelString("src/vendor/x/x.go"),
+	}
+
+	if !result.Equals(want) {
+		t.Errorf("FromDir expected %v but got %v.", want, result)
+	}
+}
+
+func TestFromDir_Fields(t *testing.T) {
+	isLarge := func(m Model) bool { return m.(FileEntry).Size > 1024 }
+	result := Collect(FromDir(testFS(), "src", nil).Filter(isLarge), ToSlice())
+
+	if len(result) != 1 {
+		t.Fatalf("Expected a single large file but got %v.", result)
+	}
+
+	entry := result[0].(FileEntry)
+	if entry.Path != "src/testdata/big.go" || entry.Size != 2048 || !entry.Mode.IsRegular() || entry.ModTime.Year() != 2021 {
+		t.Errorf("FromDir did not fill in the entry fields, got %+v.", entry)
+	}
+}
+
+func TestFromDir_SkipDir(t *testing.T) {
+	skip := func(m Model) bool {
+		name := m.(FileEntry).Path
+		return name == "src/vendor" || name == "logs"
+	}
+	result := entryPaths(FromDir(testFS(), "src", skip))
+
+	want := ModelSlice{
+		ModelString("src"),
+		ModelString("src/main.go"),
+		ModelString("src/testdata"),
+		ModelString("src/testdata/big.go"),
+	}
+
+	if !result.Equals(want) {
+		t.Errorf("FromDir with skipped directories expected %v but got %v.", want, result)
+	}
+}
+
+func TestFromDir_Cancel(t *testing.T) {
+	visited := 0
+	fsys := countingFS{FS: testFS(), visited: &visited}
+
+	source := FromDir(fsys, ".", nil)
+	result := Collect(source.Limit(2), ToSlice())
+	waitForClose(t, source)
+
+	if len(result) != 2 {
+		t.Errorf("Expected 2 entries but got %v.", len(result))
+	}
+	if visited > 2 {
+		t.Errorf("Expected the walk to stop after the limit, but %v directories were read.", visited)
+	}
+}
+
+func TestFromDir_Error(t *testing.T) {
+	s := FromDir(testFS(), "missing", nil)
+
+	if count := s.Count(); count != 0 || !errors.Is(s.Err(), fs.ErrNotExist) {
+		t.Errorf("Expected a not exist error but got %v models and error %v.", count, s.Err())
+	}
+}
+
+// countingFS counts the number of directories that are read.
+type countingFS struct {
+	fs.FS
+	visited *int
+}
+
+func (c countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	*c.visited++
+	return fs.ReadDir(c.FS, name)
+}
@@ -0,0 +1,43 @@
+package stream
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// HashFunc takes in a given Model and returns a 64 bit hash of that model.
+// Models that are equal must always produce the same hash.
+type HashFunc func(m Model) uint64
+
+// DefaultHash is the HashFunc used by the probabilistic collectors. Models
+// that implement Hasher are hashed using HashModel, and every other model is
+// hashed using its type and formatted value, so that they are not all
+// counted as a single model.
+//
+// DefaultHash only keeps the HashFunc contract for models that implement
+// Hasher. A model without a Hash method whose Equals is looser than its
+// formatted value, such as one that ignores case, can be equal to a model
+// with a different hash. Implement Hasher for such models, or pass in a
+// HashFunc that keeps the contract.
+func DefaultHash(m Model) uint64 {
+	if _, ok := m.(Hasher); ok || m == nil {
+		return HashModel(m)
+	}
+
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%T:%v", m, m)
+	return h.Sum64()
+}
+
+// mixHash spreads the bits of the hash so that the high and low bits are
+// equally random, even when the HashFunc is a weak one.
+func mixHash(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
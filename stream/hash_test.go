@@ -0,0 +1,32 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"testing"
+)
+
+type hashedModel struct {
+	id int
+}
+
+func (h hashedModel) Equals(m Model) bool {
+	return h == m
+}
+
+func (h hashedModel) Hash() uint64 {
+	return 42
+}
+
+func TestDefaultHash(t *testing.T) {
+	if DefaultHash(ModelInt(1)) != DefaultHash(ModelInt(1)) {
+		t.Error("Equal models should produce the same hash.")
+	}
+
+	if DefaultHash(ModelInt(1)) == DefaultHash(ModelByte(1)) {
+		t.Error("Models of different types should not produce the same hash.")
+	}
+
+	if DefaultHash(hashedModel{id: 1}) != 42 {
+		t.Error("Models with a Hash method should be hashed using that method.")
+	}
+}
@@ -0,0 +1,148 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+const (
+	minPrecision = 4
+	maxPrecision = 16
+)
+
+// ErrIncompatibleSketch is returned when merging two probabilistic
+// models that were not built with the same parameters.
+var ErrIncompatibleSketch = errors.New("stream: sketches built with different parameters cannot be merged")
+
+// HyperLogLog is a Model that estimates the number of distinct models that
+// have been added to it, while using a fixed amount of memory. Models that
+// are equal but hashed differently by its HashFunc are counted separately.
+//
+// The precision decides the number of registers (2^precision) that are used.
+// A higher precision uses more memory and gives a more accurate estimate,
+// with a standard error of roughly 1.04/sqrt(2^precision).
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+	hash      HashFunc
+}
+
+// NewHyperLogLog creates and returns an empty HyperLogLog. The precision is
+// kept between 4 and 16 and a nil hash will use DefaultHash.
+func NewHyperLogLog(precision uint8, hash HashFunc) *HyperLogLog {
+	if precision < minPrecision {
+		precision = minPrecision
+	}
+	if precision > maxPrecision {
+		precision = maxPrecision
+	}
+	if hash == nil {
+		hash = DefaultHash
+	}
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+		hash:      hash,
+	}
+}
+
+// Add includes the model m in the estimate.
+func (hll *HyperLogLog) Add(m Model) {
+	h := mixHash(hll.hash(m))
+	index := h >> (64 - hll.precision)
+	rest := h<<hll.precision | 1<<(hll.precision-1)
+	rank := uint8(bits.LeadingZeros64(rest) + 1)
+	if rank > hll.registers[index] {
+		hll.registers[index] = rank
+	}
+}
+
+// Count returns the estimated number of distinct models that were added.
+func (hll *HyperLogLog) Count() uint64 {
+	m := float64(len(hll.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range hll.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hllAlpha(len(hll.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge combines other into hll, so that hll estimates the distinct models
+// that were added to either of them. Both must have the same precision.
+func (hll *HyperLogLog) Merge(other *HyperLogLog) error {
+	if hll.precision != other.precision {
+		return ErrIncompatibleSketch
+	}
+	for i, r := range other.registers {
+		if r > hll.registers[i] {
+			hll.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Equals checks and returns 'true' if m is a HyperLogLog with the same
+// precision and registers as hll.
+func (hll *HyperLogLog) Equals(m Model) bool {
+	other, ok := m.(*HyperLogLog)
+	if !ok || other == nil || hll.precision != other.precision {
+		return false
+	}
+	for i, r := range hll.registers {
+		if r != other.registers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// ApproxDistinctCount builds a collector that estimates the number of
+// distinct elements in the stream using a HyperLogLog with the given
+// precision. Unlike Distinct().Count(), the elements are never held
+// in memory.
+//
+// The collector has a combiner, so it can be used with CollectParallel. The
+// combiner panics if it is given HyperLogLogs that cannot be merged.
+func ApproxDistinctCount(precision uint8) Collector[Model, *HyperLogLog, *HyperLogLog] {
+	supplier := func() *HyperLogLog { return NewHyperLogLog(precision, nil) }
+
+	accumulator := func(supp *HyperLogLog, model Model) *HyperLogLog {
+		supp.Add(model)
+		return supp
+	}
+
+	combiner := func(h1, h2 *HyperLogLog) *HyperLogLog {
+		if err := h1.Merge(h2); err != nil {
+			panic(fmt.Sprintf("stream: cannot combine HyperLogLogs: %v", err))
+		}
+		return h1
+	}
+
+	return NewCollector(supplier, accumulator, basicFinisher[*HyperLogLog]).WithCombiner(combiner)
+}
@@ -0,0 +1,55 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"math"
+	"testing"
+)
+
+func distinctInts(from, to int) ModelSlice {
+	values := make(ModelSlice, 0, to-from)
+	for i := from; i < to; i++ {
+		values = append(values, ModelInt(i))
+	}
+	return values
+}
+
+func TestApproxDistinctCount(t *testing.T) {
+	values := append(distinctInts(0, 20000), distinctInts(0, 20000)...)
+	hll := Collect(createStream(values), ApproxDistinctCount(14))
+
+	if err := math.Abs(float64(hll.Count())-20000) / 20000; err > 0.03 {
+		t.Errorf("Expected an estimate close to 20000 but got %v.", hll.Count())
+	}
+}
+
+func TestApproxDistinctCount_Small(t *testing.T) {
+	hll := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(1)}), ApproxDistinctCount(14))
+
+	if hll.Count() != 2 {
+		t.Errorf("Expected a small estimate to be exact but got %v.", hll.Count())
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	first := createStream(distinctInts(0, 6000))
+	second := createStream(distinctInts(4000, 10000))
+
+	hll := CollectParallel(ApproxDistinctCount(12), first, second)
+	if err := math.Abs(float64(hll.Count())-10000) / 10000; err > 0.05 {
+		t.Errorf("Expected the merged estimate to be close to 10000 but got %v.", hll.Count())
+	}
+
+	if NewHyperLogLog(10, nil).Merge(NewHyperLogLog(12, nil)) != ErrIncompatibleSketch {
+		t.Error("Merging HyperLogLogs of different precisions should fail.")
+	}
+}
+
+func TestApproxDistinctCount_CombineMismatched(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Combining HyperLogLogs of different precisions should panic.")
+		}
+	}()
+	ApproxDistinctCount(10).combiner(NewHyperLogLog(10, nil), NewHyperLogLog(12, nil))
+}
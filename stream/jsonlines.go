@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// JSONLinesOptions outlines how malformed lines are handled by FromJSONLines.
+// The zero value stops the stream at the first malformed line.
+type JSONLinesOptions struct {
+	// SkipMalformed skips lines that cannot be decoded instead of stopping
+	// the stream and reporting the error.
+	SkipMalformed bool
+
+	// OnMalformed, if not nil, is called with every line that is skipped.
+	OnMalformed func(err *JSONLineError)
+}
+
+// JSONLineError is the error reported when a line cannot be decoded.
+type JSONLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *JSONLineError) Error() string {
+	return "stream: json line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+func (e *JSONLineError) Unwrap() error {
+	return e.Err
+}
+
+// FromJSONLines creates a Stream of the JSON values read from r, where every
+// line holds a single JSON value that is decoded using FromJSON. Blank lines
+// are skipped.
+func FromJSONLines(r io.Reader, opts JSONLinesOptions) Stream {
+	return jsonLinesSource(r, opts, FromJSON)
+}
+
+// FromJSONLinesOf works like FromJSONLines, except that every line is
+// unmarshalled into a value of the type T using encoding/json.
+func FromJSONLinesOf[T Model](r io.Reader, opts JSONLinesOptions) Stream {
+	return jsonLinesSource(r, opts, func(line []byte) (Model, error) {
+		var v T
+		if err := json.Unmarshal(line, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+func jsonLinesSource(r io.Reader, opts JSONLinesOptions, decode func([]byte) (Model, error)) Stream {
+	reader := bufio.NewReader(r)
+	return newSource(func(send func(Model) bool) error {
+		for number := 1; ; number++ {
+			line, readErr := reader.ReadBytes('\n')
+			if readErr != nil && readErr != io.EOF {
+				return readErr
+			}
+
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				m, err := decode(line)
+				if err != nil {
+					lineErr := &JSONLineError{Line: number, Err: err}
+					if !opts.SkipMalformed {
+						return lineErr
+					}
+					if opts.OnMalformed != nil {
+						opts.OnMalformed(lineErr)
+					}
+				} else if !send(m) {
+					return nil
+				}
+			}
+
+			if readErr == io.EOF {
+				return nil
+			}
+		}
+	})
+}
+
+// WriteJSONLines is a terminal operation that writes every model in the
+// stream to w as a single line of JSON, encoded using ToJSON.
+//
+// The first error found, either while writing or by the stream, is returned.
+func (s Stream) WriteJSONLines(w io.Writer) error {
+	defer s.Close()
+	writer := bufio.NewWriter(w)
+
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
+		line, err := ToJSON(m)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return s.Err()
+}
@@ -0,0 +1,115 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"strings"
+	"testing"
+)
+
+type event struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func (e event) Equals(m Model) bool {
+	return e == m
+}
+
+func TestFromJSONLines(t *testing.T) {
+	input := `{"user":{"name":"Alex","tags":["a","b"]},"active":true,"score":1.5,"count":3,"note":null}` + "\n\n" + `[1,"two"]`
+	result := Collect(FromJSONLines(strings.NewReader(input), JSONLinesOptions{}), ToSlice())
+
+	want := ModelSlice{
+		ModelMap{
+			ModelString("user"): ModelMap{
+				ModelString("name"): ModelString("Alex"),
+				ModelString("tags"): ModelSlice{ModelString("a"), ModelString("b")},
+			},
+			ModelString("active"): ModelBool(true),
+			ModelString("score"):  ModelFloat64(1.5),
+			ModelString("count"):  ModelInt(3),
+			ModelString("note"):   nil,
+		},
+		ModelSlice{ModelInt(1), ModelString("two")},
+	}
+
+	if d, ok := DiffFirst(want, result); ok {
+		t.Errorf("FromJSONLines did not decode the expected models, %v.", d)
+	}
+}
+
+func TestFromJSONLines_Malformed(t *testing.T) {
+	input := "{\"id\":1}\n{broken\n{\"id\":3}\n"
+
+	s := FromJSONLines(strings.NewReader(input), JSONLinesOptions{})
+	if count := s.Count(); count != 1 {
+		t.Errorf("Expected the stream to stop at the malformed line but got %v models.", count)
+	}
+
+	var lineErr *JSONLineError
+	if !errors.As(s.Err(), &lineErr) || lineErr.Line != 2 {
+		t.Errorf("Expected an error for line 2 but got %v.", s.Err())
+	}
+
+	var skipped []int
+	opts := JSONLinesOptions{
+		SkipMalformed: true,
+		OnMalformed:   func(err *JSONLineError) { skipped = append(skipped, err.Line) },
+	}
+	s = FromJSONLines(strings.NewReader(input), opts)
+	if count := s.Count(); count != 2 || s.Err() != nil {
+		t.Errorf("Expected the malformed line to be skipped but got %v models and error %v.", count, s.Err())
+	}
+	if len(skipped) != 1 || skipped[0] != 2 {
+		t.Errorf("Expected OnMalformed to be called for line 2 but got %v.", skipped)
+	}
+}
+
+func TestFromJSONLinesOf(t *testing.T) {
+	input := "{\"id\":1,\"kind\":\"click\"}\n{\"id\":2,\"kind\":\"view\"}\n"
+	result := Collect(FromJSONLinesOf[event](strings.NewReader(input), JSONLinesOptions{}), ToSlice())
+
+	want := ModelSlice{event{ID: 1, Kind: "click"}, event{ID: 2, Kind: "view"}}
+	if !result.Equals(want) {
+		t.Errorf("FromJSONLinesOf expected %v but got %v.", want, result)
+	}
+}
+
+func TestFromJSONLines_Paths(t *testing.T) {
+	input := `{"user":{"name":"Alex","active":true,"addresses":[{"city":"Toronto"}]}}
+{"user":{"name":"Sam","active":false}}
+{"user":{"name":"Kim","active":true,"addresses":[{"city":"Ottawa"}]}}`
+
+	result := Collect(FromJSONLines(strings.NewReader(input), JSONLinesOptions{}).
+		Filter(PathEq("user.active", true)).
+		Map(PathOf("user.addresses[0].city")), ToSlice())
+
+	want := ModelSlice{ModelString("Toronto"), ModelString("Ottawa")}
+	if !result.Equals(want) {
+		t.Errorf("Expected the cities of the active users %v but got %v.", want, result)
+	}
+}
+
+func TestStream_WriteJSONLines(t *testing.T) {
+	linked := NewModelLinkedMap()
+	linked.Put(ModelString("z"), ModelInt(1))
+	linked.Put(ModelString("a"), ModelSlice{ModelBool(false), nil})
+
+	models := ModelSlice{
+		ModelMap{ModelString("b"): ModelString("x\"y"), ModelInt(1): ModelFloat(2.5)},
+		linked,
+		event{ID: 7, Kind: "click"},
+	}
+
+	var buf bytes.Buffer
+	if err := NewStreamFromSlice(models).WriteJSONLines(&buf); err != nil {
+		t.Fatalf("WriteJSONLines returned an unexpected error %v.", err)
+	}
+
+	want := `{"1":2.5,"b":"x\"y"}` + "\n" + `{"z":1,"a":[false,null]}` + "\n" + `{"id":7,"kind":"click"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteJSONLines expected %q but got %q.", want, buf.String())
+	}
+}
@@ -21,3 +21,30 @@ type BiOperator func(m1, m2 Model) Model
 // This is especially when using the FlatMap() method in streams. It is used
 // like a one to many operation.
 type MultiOperator func(m Model) []Model
+
+// Comparator compares two models and returns a negative number when m1
+// should be ordered before m2, a positive number when m1 should be ordered
+// after m2 and zero when the order of the two models does not matter.
+//
+// Wherever a Comparator is taken, passing nil will use NaturalOrder.
+type Comparator func(m1, m2 Model) int
+
+// NaturalOrder is a Comparator that uses the natural ordering of models
+// that implement the Ordered interface. It will panic if the models are
+// not Ordered, or cannot be compared with each other.
+func NaturalOrder(m1, m2 Model) int {
+	return CompareModels(m1, m2)
+}
+
+// orNatural returns cmp, or NaturalOrder when cmp is nil.
+func orNatural(cmp Comparator) Comparator {
+	if cmp == nil {
+		return NaturalOrder
+	}
+	return cmp
+}
+
+// NumericExtractor takes in a given Model and extracts a numeric value
+// from it that can be used in calculations, such as when summarizing
+// the models within a stream.
+type NumericExtractor func(m Model) float64
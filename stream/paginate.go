@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"time"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// PageFetcher fetches the page of models at the given cursor, and returns
+// the cursor of the following page. Returning the zero value of the cursor
+// means that there are no more pages.
+type PageFetcher[C comparable] func(cursor C) (page ModelSlice, next C, err error)
+
+// PaginateOptions outlines how Paginate retries a page that failed to be
+// fetched. The zero value does not retry.
+type PaginateOptions struct {
+	// MaxRetries is the number of times a failed fetch is tried again
+	// before the error is reported.
+	MaxRetries int
+
+	// RetryDelay is the time waited before the first retry, and is doubled
+	// before every retry after it.
+	RetryDelay time.Duration
+}
+
+// Paginate creates a Stream of the models found on every page returned by
+// fetch, starting with the page at the start cursor. This is useful for
+// reading from cursor based APIs.
+//
+// Pages are fetched lazily. No page is fetched until the first model is asked
+// for, and the next page is only fetched once every model of the current page
+// has been consumed and another model is asked for, so an operation such as
+// Limit or FindFirst stops any further pages from being fetched. The stream ends once
+// fetch returns the zero value of the cursor, and an error that remains after
+// every retry is reported by Err.
+func Paginate[C comparable](start C, fetch PageFetcher[C], opts PaginateOptions) Stream {
+	s := NewStream(make(chan Model))
+
+	go func() {
+		defer close(s.ch)
+		var zero C
+		cursor := start
+		for s.ready() {
+			page, next, err := fetchWithRetry(s, cursor, fetch, opts)
+			if err != nil {
+				s.state.setErr(err)
+				return
+			}
+
+			for _, m := range page {
+				if !s.send(m) {
+					return
+				}
+			}
+
+			if next == zero {
+				return
+			}
+			cursor = next
+		}
+	}()
+
+	return s
+}
+
+// fetchWithRetry fetches the page at the cursor, retrying failures as
+// outlined by the options. Waiting for a retry stops early if the stream
+// is cancelled.
+func fetchWithRetry[C comparable](s Stream, cursor C, fetch PageFetcher[C], opts PaginateOptions) (ModelSlice, C, error) {
+	delay := opts.RetryDelay
+	for attempt := 0; ; attempt++ {
+		page, next, err := fetch(cursor)
+		if err == nil || attempt >= opts.MaxRetries {
+			return page, next, err
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-s.done.ch:
+			var zero C
+			return nil, zero, nil
+		}
+		delay *= 2
+	}
+}
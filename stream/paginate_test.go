@@ -0,0 +1,150 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+type pageResponse struct {
+	Items []int `json:"items"`
+	Next  int   `json:"next"`
+}
+
+// pagedServer serves the numbers 1 to total in pages of size, where the
+// cursor is the number to start from. The first failures requests fail.
+func pagedServer(t *testing.T, total, size int, failures int32, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		resp := pageResponse{}
+		for i := start; i < start+size && i <= total; i++ {
+			resp.Items = append(resp.Items, i)
+		}
+		if start+size <= total {
+			resp.Next = start + size
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func httpFetcher(url string) PageFetcher[int] {
+	return func(cursor int) (ModelSlice, int, error) {
+		resp, err := http.Get(fmt.Sprintf("%v?cursor=%v", url, cursor))
+		if err != nil {
+			return nil, 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, 0, errors.New(resp.Status)
+		}
+
+		var page pageResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			return nil, 0, err
+		}
+
+		models := ModelSlice{}
+		for _, item := range page.Items {
+			models = append(models, ModelInt(item))
+		}
+		return models, page.Next, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	var requests int32
+	server := pagedServer(t, 7, 3, 0, &requests)
+
+	s := Paginate(1, httpFetcher(server.URL), PaginateOptions{})
+	result := Collect(s, ToSlice())
+
+	if !result.Equals(distinctInts(1, 8)) {
+		t.Errorf("Paginate expected every model from every page but got %v.", result)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 || s.Err() != nil {
+		t.Errorf("Expected 3 pages to be fetched without error but got %v and %v.", n, s.Err())
+	}
+}
+
+func TestPaginate_Limit(t *testing.T) {
+	var requests int32
+	server := pagedServer(t, 100, 3, 0, &requests)
+
+	source := Paginate(1, httpFetcher(server.URL), PaginateOptions{})
+	result := Collect(source.Limit(4), ToSlice())
+	waitForClose(t, source)
+
+	if !result.Equals(distinctInts(1, 5)) {
+		t.Errorf("Paginate with a limit expected the first 4 models but got %v.", result)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected only the 2 pages needed to be fetched but %v were fetched.", n)
+	}
+}
+
+func TestPaginate_OnDemand(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		var fetches int32
+		fetch := func(cursor int) (ModelSlice, int, error) {
+			atomic.AddInt32(&fetches, 1)
+			return distinctInts(cursor, cursor+3), cursor + 3, nil
+		}
+
+		source := Paginate(1, fetch, PaginateOptions{})
+		mapped := source.Map(func(m Model) Model { return m.(ModelInt) * 2 })
+		if n := atomic.LoadInt32(&fetches); n != 0 {
+			t.Fatalf("Expected no page to be fetched before a model is asked for but %v were fetched.", n)
+		}
+
+		result := Collect(mapped.Limit(3), ToSlice())
+		waitForClose(t, source)
+
+		if !result.Equals(ModelSlice{ModelInt(2), ModelInt(4), ModelInt(6)}) {
+			t.Fatalf("Expected the first page to be mapped but got %v.", result)
+		}
+		if n := atomic.LoadInt32(&fetches); n != 1 {
+			t.Fatalf("Expected only the first page to be fetched but %v were fetched.", n)
+		}
+	}
+}
+
+func TestPaginate_Retry(t *testing.T) {
+	var requests int32
+	server := pagedServer(t, 2, 5, 2, &requests)
+
+	s := Paginate(1, httpFetcher(server.URL), PaginateOptions{MaxRetries: 2})
+	result := Collect(s, ToSlice())
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(2)}) || s.Err() != nil {
+		t.Errorf("Expected the page to be fetched after retrying but got %v and %v.", result, s.Err())
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("Expected 3 requests to be made but %v were made.", n)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	var requests int32
+	server := pagedServer(t, 2, 5, 10, &requests)
+
+	s := Paginate(1, httpFetcher(server.URL), PaginateOptions{MaxRetries: 1})
+	if count := s.Count(); count != 0 || s.Err() == nil {
+		t.Errorf("Expected the fetch error to be reported but got %v models and error %v.", count, s.Err())
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected 2 requests to be made but %v were made.", n)
+	}
+}
@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"math/rand"
+	"time"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// randOrDefault returns rng, or a randomly seeded source when rng is nil.
+func randOrDefault(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return rng
+}
+
+// Sample returns a Stream in which each model is kept with a probability of
+// fraction, which should be between 0 and 1. The order of the kept models
+// is unchanged.
+//
+// Pass in a seeded rng, such as rand.New(rand.NewSource(1)), for a sample
+// that is the same on every run. A nil rng uses a randomly seeded source.
+func (s Stream) Sample(fraction float64, rng *rand.Rand) Stream {
+	rng = randOrDefault(rng)
+	return s.Filter(func(m Model) bool {
+		return rng.Float64() < fraction
+	})
+}
+
+// Reservoir is the container used by SampleN to hold the sampled models,
+// along with how many models have been seen so far.
+type Reservoir struct {
+	k      int
+	seen   int
+	rng    *rand.Rand
+	models ModelSlice
+}
+
+// SampleN builds a collector that uses reservoir sampling to pick k elements
+// uniformly at random from the stream, without ever holding more than k
+// elements in memory. If the stream has fewer than k elements, then every
+// element is returned.
+//
+// Pass in a seeded rng for a sample that is the same on every run. A nil
+// rng uses a randomly seeded source.
+func SampleN(k int, rng *rand.Rand) Collector[Model, *Reservoir, ModelSlice] {
+	supplier := func() *Reservoir {
+		return &Reservoir{k: k, rng: randOrDefault(rng), models: ModelSlice{}}
+	}
+
+	accumulator := func(supp *Reservoir, model Model) *Reservoir {
+		supp.seen++
+		if len(supp.models) < supp.k {
+			supp.models = append(supp.models, model)
+		} else if i := supp.rng.Intn(supp.seen); i < supp.k {
+			supp.models[i] = model
+		}
+		return supp
+	}
+
+	finisher := func(supp *Reservoir) ModelSlice {
+		return supp.models
+	}
+
+	return NewCollector(supplier, accumulator, finisher)
+}
+
+// Shuffle returns a Stream that contains all the same models in a random
+// order. Every model must be read before the first can be passed on, so the
+// whole stream is held in memory.
+//
+// Pass in a seeded rng for an order that is the same on every run. A nil
+// rng uses a randomly seeded source.
+func (s Stream) Shuffle(rng *rand.Rand) Stream {
+	rng = randOrDefault(rng)
+
+	return s.pipe(func(next Stream) {
+		var models ModelSlice
+		for m, ok := s.pull(next); ok; m, ok = s.pull(next) {
+			models = append(models, m)
+		}
+
+		rng.Shuffle(len(models), func(i, j int) {
+			models[i], models[j] = models[j], models[i]
+		})
+		for _, m := range models {
+			if !next.send(m) {
+				return
+			}
+		}
+	})
+}
+
+// RandomSplit randomly routes each model into one of several streams, such as
+// when building training and test sets. The fractions are the relative weights
+// of each returned stream, so []float64{0.8, 0.2} will place about 80% of the
+// models into the first stream and 20% into the second.
+//
+// Each model is placed into exactly one stream and the original order is kept
+// within each stream. The models are held in memory until the input stream is
+// finished, so the returned streams can be consumed in any order. The input
+// stream is cancelled once every returned stream has been closed, while it is
+// still being read.
+//
+// Pass in a seeded rng for a split that is the same on every run. A nil
+// rng uses a randomly seeded source.
+func (s Stream) RandomSplit(fractions []float64, rng *rand.Rand) []Stream {
+	rng = randOrDefault(rng)
+	total := 0.0
+	for _, f := range fractions {
+		total += f
+	}
+
+	streams := make([]Stream, len(fractions))
+	for i := range fractions {
+		streams[i] = Stream{
+			ch:     make(chan Model),
+			done:   newSignal(nil),
+			state:  s.state,
+			demand: newDemand(),
+		}
+	}
+
+	read := make(chan struct{})
+	go func() {
+		for _, split := range streams {
+			select {
+			case <-split.done.ch:
+			case <-read:
+				return
+			}
+		}
+		s.Close()
+	}()
+
+	go func() {
+		buckets := make([]ModelSlice, len(fractions))
+		for m, ok := s.recv(); ok; m, ok = s.recv() {
+			if len(buckets) == 0 {
+				continue
+			}
+			i := pickBucket(fractions, total, rng)
+			buckets[i] = append(buckets[i], m)
+		}
+		close(read)
+		s.Close()
+
+		for i, bucket := range buckets {
+			go func(split Stream, bucket ModelSlice) {
+				defer close(split.ch)
+				for _, m := range bucket {
+					if !split.send(m) {
+						return
+					}
+				}
+			}(streams[i], bucket)
+		}
+	}()
+
+	return streams
+}
+
+// pickBucket randomly picks an index of fractions, weighted by each fraction.
+func pickBucket(fractions []float64, total float64, rng *rand.Rand) int {
+	r := rng.Float64() * total
+	for i, f := range fractions {
+		if r < f {
+			return i
+		}
+		r -= f
+	}
+	return len(fractions) - 1
+}
@@ -0,0 +1,119 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestStream_Sample(t *testing.T) {
+	values := distinctInts(0, 10000)
+
+	first := Collect(createStream(values).Sample(0.1, rand.New(rand.NewSource(7))), ToSlice())
+	second := Collect(createStream(values).Sample(0.1, rand.New(rand.NewSource(7))), ToSlice())
+
+	if !first.Equals(second) {
+		t.Error("Sampling with the same seed should return the same models.")
+	}
+
+	if len(first) < 800 || len(first) > 1200 {
+		t.Errorf("Expected roughly 1000 sampled models but got %v.", len(first))
+	}
+
+	for i := 1; i < len(first); i++ {
+		if first[i-1].(ModelInt) >= first[i].(ModelInt) {
+			t.Fatal("Sample should keep the models in their original order.")
+		}
+	}
+}
+
+func TestSampleN(t *testing.T) {
+	values := distinctInts(0, 1000)
+
+	first := Collect(createStream(values), SampleN(10, rand.New(rand.NewSource(3))))
+	second := Collect(createStream(values), SampleN(10, rand.New(rand.NewSource(3))))
+
+	if len(first) != 10 {
+		t.Errorf("Expected 10 sampled models but got %v.", len(first))
+	}
+
+	if !first.Equals(second) {
+		t.Error("Sampling with the same seed should return the same models.")
+	}
+
+	if distinct := Collect(createStream(first), ToSet()); distinct.Len() != 10 {
+		t.Error("SampleN should never pick the same element twice.")
+	}
+
+	small := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2)}), SampleN(5, nil))
+	if !small.Equals(ModelSlice{ModelInt(1), ModelInt(2)}) {
+		t.Errorf("A stream smaller than k should return every model, got %v.", small)
+	}
+}
+
+func TestStream_Shuffle(t *testing.T) {
+	values := distinctInts(0, 100)
+
+	first := Collect(createStream(values).Shuffle(rand.New(rand.NewSource(5))), ToSlice())
+	second := Collect(createStream(values).Shuffle(rand.New(rand.NewSource(5))), ToSlice())
+
+	if !first.Equals(second) {
+		t.Error("Shuffling with the same seed should return the same order.")
+	}
+
+	if first.Equals(values) {
+		t.Error("Shuffled stream should not be in the original order.")
+	}
+
+	sorted := Collect(createStream(first), ToSortedSlice(compareInts))
+	if !sorted.Equals(values) {
+		t.Error("Shuffled stream should contain exactly the original models.")
+	}
+}
+
+func TestStream_RandomSplit(t *testing.T) {
+	values := distinctInts(0, 1000)
+	splits := createStream(values).RandomSplit([]float64{0.8, 0.2}, rand.New(rand.NewSource(11)))
+
+	if len(splits) != 2 {
+		t.Fatalf("Expected 2 streams but got %v.", len(splits))
+	}
+
+	// The second stream is consumed first to show that the order does not matter.
+	test := Collect(splits[1], ToSlice())
+	train := Collect(splits[0], ToSlice())
+
+	if len(train)+len(test) != 1000 {
+		t.Errorf("Expected all 1000 models to be split but got %v.", len(train)+len(test))
+	}
+
+	if len(test) < 150 || len(test) > 250 {
+		t.Errorf("Expected roughly 200 models in the second stream but got %v.", len(test))
+	}
+
+	all := Collect(NewStreamFromSlice(append(train, test...)), ToSortedSlice(compareInts))
+	if !all.Equals(values) {
+		t.Error("Every model should be placed in exactly one stream.")
+	}
+
+	again := createStream(values).RandomSplit([]float64{0.8, 0.2}, rand.New(rand.NewSource(11)))
+	if !Collect(again[1], ToSlice()).Equals(test) {
+		t.Error("Splitting with the same seed should return the same streams.")
+	}
+	Collect(again[0], Counting())
+}
+
+func TestStream_RandomSplit_Close(t *testing.T) {
+	source := FromLines(endlessReader{})
+	splits := source.RandomSplit([]float64{0.5, 0.5}, nil)
+
+	splits[0].Close()
+	time.Sleep(10 * time.Millisecond)
+	if source.cancelled() {
+		t.Error("The source should not be cancelled while a split is still open.")
+	}
+
+	splits[1].Close()
+	waitForClose(t, source)
+}
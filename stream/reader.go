@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"bufio"
+	"io"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// FromScanner creates a Stream that contains a ModelString for every token
+// found by the scanner. Any error found while scanning is reported by Err
+// once the stream has finished.
+//
+// If the stream is closed early, the scanner simply stops being read. It is
+// left to the caller to close the underlying reader.
+func FromScanner(scanner *bufio.Scanner) Stream {
+	return newSource(func(send func(Model) bool) error {
+		for scanner.Scan() {
+			if !send(ModelString(scanner.Text())) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	})
+}
+
+// FromLines creates a Stream that contains a ModelString for every line
+// read from r, without the trailing newline.
+func FromLines(r io.Reader) Stream {
+	return FromScanner(bufio.NewScanner(r))
+}
+
+// FromBytes creates a Stream that contains a ModelByte for every byte
+// read from r. Any read error is reported by Err.
+func FromBytes(r io.Reader) Stream {
+	reader := bufio.NewReader(r)
+	return newSource(func(send func(Model) bool) error {
+		for {
+			b, err := reader.ReadByte()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !send(ModelByte(b)) {
+				return nil
+			}
+		}
+	})
+}
+
+// FromRunes creates a Stream that contains a ModelRune for every UTF-8
+// encoded rune read from r. Invalid encodings are passed on as the
+// unicode.ReplacementChar. Any read error is reported by Err.
+func FromRunes(r io.Reader) Stream {
+	reader := bufio.NewReader(r)
+	return newSource(func(send func(Model) bool) error {
+		for {
+			ru, _, err := reader.ReadRune()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !send(ModelRune(ru)) {
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// endlessReader is an io.Reader that never runs out of lines.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+		if i%4 == 3 {
+			p[i] = '\n'
+		}
+	}
+	return len(p), nil
+}
+
+// waitForClose fails the test if the source stream does not close in time.
+func waitForClose(t *testing.T, s Stream) {
+	t.Helper()
+	closed := make(chan struct{})
+	go func() {
+		for _, ok := s.recv(); ok; _, ok = s.recv() {
+		}
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("The source stream was not closed after the stream was cancelled.")
+	}
+}
+
+func TestFromLines(t *testing.T) {
+	s := FromLines(strings.NewReader("first\nsecond\n\nlast"))
+	result := Collect(s, ToSlice())
+
+	want := ModelSlice{ModelString("first"), ModelString("second"), ModelString(""), ModelString("last")}
+	if !result.Equals(want) {
+		t.Errorf("FromLines expected %v but got %v.", want, result)
+	}
+
+	if s.Err() != nil {
+		t.Errorf("FromLines reported an unexpected error %v.", s.Err())
+	}
+}
+
+func TestFromLines_Error(t *testing.T) {
+	readErr := errors.New("disk failure")
+	r := io.MultiReader(strings.NewReader("first\nsecond\n"), iotest.ErrReader(readErr))
+
+	s := FromLines(r).Map(func(m Model) Model { return m })
+	count := s.Count()
+
+	if count != 2 {
+		t.Errorf("Expected the 2 lines before the error but got %v.", count)
+	}
+
+	if !errors.Is(s.Err(), readErr) {
+		t.Errorf("Expected the read error to be reported but got %v.", s.Err())
+	}
+}
+
+func TestFromLines_Limit(t *testing.T) {
+	source := FromLines(endlessReader{})
+	result := Collect(source.Filter(func(m Model) bool { return true }).Limit(3), ToSlice())
+
+	if len(result) != 3 {
+		t.Errorf("Expected 3 lines but got %v.", len(result))
+	}
+	waitForClose(t, source)
+}
+
+func TestFromLines_FindFirst(t *testing.T) {
+	source := FromLines(endlessReader{})
+	result := source.FindFirst(func(m Model) bool { return true })
+
+	if m, _ := result.Get(); !ModelsEqual(m, ModelString("aaa")) {
+		t.Errorf("Expected to find the first line but got %v.", m)
+	}
+	waitForClose(t, source)
+}
+
+func TestFromScanner(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("fun  go\tstreams"))
+	scanner.Split(bufio.ScanWords)
+
+	result := Collect(FromScanner(scanner), ToSlice())
+	want := ModelSlice{ModelString("fun"), ModelString("go"), ModelString("streams")}
+	if !result.Equals(want) {
+		t.Errorf("FromScanner expected %v but got %v.", want, result)
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	result := Collect(FromBytes(strings.NewReader("go")), ToSlice())
+
+	if !result.Equals(ModelSlice{ModelByte('g'), ModelByte('o')}) {
+		t.Errorf("FromBytes expected every byte but got %v.", result)
+	}
+}
+
+func TestFromRunes(t *testing.T) {
+	result := Collect(FromRunes(strings.NewReader("hé")), ToSlice())
+
+	if !result.Equals(ModelSlice{ModelRune('h'), ModelRune('é')}) {
+		t.Errorf("FromRunes expected every rune but got %v.", result)
+	}
+}
+
+func TestStream_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := FromLines(endlessReader{})
+	s := source.WithContext(ctx)
+
+	count := 0
+	s.ForEach(func(m Model) {
+		count++
+		if count == 5 {
+			cancel()
+		}
+	})
+
+	if !errors.Is(s.Err(), context.Canceled) {
+		t.Errorf("Expected the context error to be reported but got %v.", s.Err())
+	}
+	waitForClose(t, source)
+}
+
+func TestStream_Close(t *testing.T) {
+	source := FromLines(endlessReader{})
+	mapped := source.Map(func(m Model) Model { return m })
+	mapped.recv()
+	mapped.Close()
+
+	waitForClose(t, source)
+	if source.Err() != nil {
+		t.Errorf("Closing a stream should not report an error, got %v.", source.Err())
+	}
+}
@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"fmt"
+	"io"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// Formatter converts a Model into the bytes that are written for it.
+type Formatter func(m Model) ([]byte, error)
+
+// LineFormatter formats every model using its default format followed
+// by a newline.
+func LineFormatter(m Model) ([]byte, error) {
+	return []byte(fmt.Sprintln(m)), nil
+}
+
+// ToChannel is a terminal operation that sends every model in the stream to
+// ch, waiting for each one to be received. When closeWhenDone is 'true', ch
+// is closed once every model has been sent.
+func (s Stream) ToChannel(ch chan<- Model, closeWhenDone bool) {
+	defer s.Close()
+	if closeWhenDone {
+		defer close(ch)
+	}
+
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
+		ch <- m
+	}
+}
+
+// IntoChannel is a terminal operation that returns a channel which receives
+// every model in the stream, and is closed once the stream is finished.
+//
+// If the channel is not read until it is closed, then Close should be called
+// on the stream so that the source stops producing models.
+func (s Stream) IntoChannel() <-chan Model {
+	ch := make(chan Model)
+	go func() {
+		defer close(ch)
+		for m, ok := s.recv(); ok; m, ok = s.recv() {
+			select {
+			case ch <- m:
+			case <-s.done.ch:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// WriteTo is a terminal operation that writes every model in the stream to w
+// using the LineFormatter. It returns the number of bytes written and the
+// first error found, either while writing or by the stream.
+//
+// This allows a Stream to be used as an io.WriterTo.
+func (s Stream) WriteTo(w io.Writer) (int64, error) {
+	return s.WriteWith(w, LineFormatter)
+}
+
+// WriteWith is a terminal operation that writes every model in the stream to
+// w using the formatter. It returns the number of bytes written and the first
+// error found, either while formatting, writing or by the stream. Nothing more
+// is written after the first error.
+func (s Stream) WriteWith(w io.Writer, formatter Formatter) (int64, error) {
+	defer s.Close()
+
+	var written int64
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
+		b, err := formatter(m)
+		if err != nil {
+			return written, err
+		}
+
+		n, err := w.Write(b)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, s.Err()
+}
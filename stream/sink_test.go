@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStream_ToChannel(t *testing.T) {
+	ch := make(chan Model)
+	go createStream(ModelSlice{ModelInt(1), ModelInt(2)}).ToChannel(ch, true)
+
+	var result ModelSlice
+	for m := range ch {
+		result = append(result, m)
+	}
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(2)}) {
+		t.Errorf("ToChannel expected every model to be sent but got %v.", result)
+	}
+}
+
+func TestStream_ToChannel_Open(t *testing.T) {
+	ch := make(chan Model, 3)
+	createStream(ModelSlice{ModelInt(1), ModelInt(2)}).ToChannel(ch, false)
+	ch <- ModelInt(3)
+
+	if len(ch) != 3 {
+		t.Errorf("Expected the channel to remain open and hold 3 models but it holds %v.", len(ch))
+	}
+}
+
+func TestStream_IntoChannel(t *testing.T) {
+	ch := createStream(ModelSlice{ModelInt(1), ModelInt(2)}).Map(func(m Model) Model {
+		return m.(ModelInt) * 2
+	}).IntoChannel()
+
+	var result ModelSlice
+	for m := range ch {
+		result = append(result, m)
+	}
+
+	if !result.Equals(ModelSlice{ModelInt(2), ModelInt(4)}) {
+		t.Errorf("IntoChannel expected every model to be received but got %v.", result)
+	}
+}
+
+func TestStream_WriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	var writerTo io.WriterTo = createStream(ModelSlice{ModelInt(1), ModelString("two")})
+
+	n, err := writerTo.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo returned an unexpected error %v.", err)
+	}
+
+	if buf.String() != "1\ntwo\n" || n != int64(buf.Len()) {
+		t.Errorf("WriteTo expected %q but wrote %q and reported %v bytes.", "1\ntwo\n", buf.String(), n)
+	}
+}
+
+func TestStream_WriteWith(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := func(m Model) ([]byte, error) {
+		return []byte(fmt.Sprintf("<%v>", m)), nil
+	}
+
+	n, err := createStream(ModelSlice{ModelInt(1), ModelInt(22)}).WriteWith(&buf, formatter)
+	if err != nil || buf.String() != "<1><22>" || n != 7 {
+		t.Errorf("WriteWith expected %q but wrote %q, %v bytes and error %v.", "<1><22>", buf.String(), n, err)
+	}
+}
+
+func TestStream_WriteWith_Errors(t *testing.T) {
+	writeErr := errors.New("disk full")
+	failing := failingWriter{err: writeErr}
+
+	n, err := FromLines(strings.NewReader("a\nb\n")).WriteTo(failing)
+	if !errors.Is(err, writeErr) || n != 0 {
+		t.Errorf("Expected the write error to be returned but got %v bytes and error %v.", n, err)
+	}
+
+	formatErr := errors.New("cannot format")
+	_, err = createStream(ModelSlice{ModelInt(1)}).WriteWith(io.Discard, func(Model) ([]byte, error) {
+		return nil, formatErr
+	})
+	if !errors.Is(err, formatErr) {
+		t.Errorf("Expected the formatter error to be returned but got %v.", err)
+	}
+
+	readErr := errors.New("read failure")
+	_, err = FromLines(iotest.ErrReader(readErr)).WriteTo(io.Discard)
+	if !errors.Is(err, readErr) {
+		t.Errorf("Expected the stream error to be returned but got %v.", err)
+	}
+}
+
+// failingWriter fails every write with err.
+type failingWriter struct {
+	err error
+}
+
+func (f failingWriter) Write([]byte) (int, error) {
+	return 0, f.err
+}
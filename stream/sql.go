@@ -0,0 +1,33 @@
+package stream
+
+import (
+	"database/sql"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// RowScanner scans the current row into a Model.
+type RowScanner func(rows *sql.Rows) (Model, error)
+
+// FromRows creates a Stream that contains a Model for every row of the
+// query result, using scan to convert each row.
+//
+// The rows are always closed once the stream is done, whether every row was
+// read, the stream was cancelled, or an operation such as Limit or FindFirst
+// finished early. An error returned by scan stops the stream, and it is
+// reported by Err alongside any error from rows.Err().
+func FromRows(rows *sql.Rows, scan RowScanner) Stream {
+	return newSource(func(send func(Model) bool) error {
+		defer rows.Close()
+		for rows.Next() {
+			m, err := scan(rows)
+			if err != nil {
+				return err
+			}
+			if !send(m) {
+				return nil
+			}
+		}
+		return rows.Err()
+	})
+}
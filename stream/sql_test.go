@@ -0,0 +1,172 @@
+package stream
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDriver serves a single column of incrementing integers. The DSN
+// decides the number of rows and the row, if any, at which the rows fail.
+type fakeDriver struct {
+	closed int32
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+	total  int
+	fail   int
+}
+
+type fakeStmt struct {
+	conn *fakeConn
+}
+
+type fakeRows struct {
+	conn *fakeConn
+	next int
+}
+
+var errFakeRows = errors.New("connection lost")
+
+var testDriver = &fakeDriver{}
+
+func init() {
+	sql.Register("fungo-fake", testDriver)
+}
+
+func (d *fakeDriver) Open(dsn string) (driver.Conn, error) {
+	c := &fakeConn{driver: d}
+	_, err := fmt.Sscanf(dsn, "rows=%d fail=%d", &c.total, &c.fail)
+	return c, err
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{conn: c}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return 0 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) { return &fakeRows{conn: s.conn}, nil }
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+
+func (r *fakeRows) Close() error {
+	atomic.AddInt32(&r.conn.driver.closed, 1)
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	r.next++
+	if r.conn.fail > 0 && r.next == r.conn.fail {
+		return errFakeRows
+	}
+	if r.conn.total >= 0 && r.next > r.conn.total {
+		return io.EOF
+	}
+	dest[0] = int64(r.next)
+	return nil
+}
+
+func queryFake(t *testing.T, dsn string) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("fungo-fake", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query("SELECT id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func scanID(rows *sql.Rows) (Model, error) {
+	var id int
+	err := rows.Scan(&id)
+	return ModelInt(id), err
+}
+
+// waitForRowsClosed fails the test if the rows are not closed in time.
+func waitForRowsClosed(t *testing.T, before int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&testDriver.closed) == before {
+		if time.Now().After(deadline) {
+			t.Error("The rows were not closed.")
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFromRows(t *testing.T) {
+	before := atomic.LoadInt32(&testDriver.closed)
+	s := FromRows(queryFake(t, "rows=3 fail=0"), scanID)
+	result := Collect(s, ToSlice())
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}) {
+		t.Errorf("FromRows expected every row but got %v.", result)
+	}
+	if s.Err() != nil {
+		t.Errorf("FromRows reported an unexpected error %v.", s.Err())
+	}
+	waitForRowsClosed(t, before)
+}
+
+func TestFromRows_Limit(t *testing.T) {
+	before := atomic.LoadInt32(&testDriver.closed)
+	result := Collect(FromRows(queryFake(t, "rows=-1 fail=0"), scanID).Limit(2), ToSlice())
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(2)}) {
+		t.Errorf("FromRows with a limit expected 2 rows but got %v.", result)
+	}
+	waitForRowsClosed(t, before)
+}
+
+func TestFromRows_FindFirst(t *testing.T) {
+	before := atomic.LoadInt32(&testDriver.closed)
+	result := FromRows(queryFake(t, "rows=-1 fail=0"), scanID).FindFirst(func(m Model) bool {
+		return m.(ModelInt) > 4
+	})
+
+	if m, _ := result.Get(); !ModelsEqual(m, ModelInt(5)) {
+		t.Errorf("Expected to find the fifth row but got %v.", m)
+	}
+	waitForRowsClosed(t, before)
+}
+
+func TestFromRows_Error(t *testing.T) {
+	before := atomic.LoadInt32(&testDriver.closed)
+	s := FromRows(queryFake(t, "rows=10 fail=3"), scanID)
+
+	if count := s.Count(); count != 2 {
+		t.Errorf("Expected the 2 rows before the failure but got %v.", count)
+	}
+	if !errors.Is(s.Err(), errFakeRows) {
+		t.Errorf("Expected rows.Err() to be reported but got %v.", s.Err())
+	}
+	waitForRowsClosed(t, before)
+}
+
+func TestFromRows_ScanError(t *testing.T) {
+	scanErr := errors.New("bad row")
+	s := FromRows(queryFake(t, "rows=3 fail=0"), func(rows *sql.Rows) (Model, error) {
+		return nil, scanErr
+	})
+
+	if count := s.Count(); count != 0 || !errors.Is(s.Err(), scanErr) {
+		t.Errorf("Expected the scan error to stop the stream but got %v models and error %v.", count, s.Err())
+	}
+}
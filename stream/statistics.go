@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"math"
+	"sort"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// Statistics is a Model that holds a summary of numeric values, such as
+// the count, sum, min, max, mean and standard deviation. Every value is
+// kept so that exact percentiles can be calculated.
+//
+// Any statistic that is undefined for an empty set of values, such as the
+// mean or min, will be returned as NaN.
+type Statistics struct {
+	count  int
+	sum    float64
+	min    float64
+	max    float64
+	mean   float64
+	m2     float64
+	values []float64
+	sorted bool
+}
+
+// NewStatistics creates and returns an empty Statistics.
+func NewStatistics() *Statistics {
+	return &Statistics{
+		min:    math.NaN(),
+		max:    math.NaN(),
+		sorted: true,
+	}
+}
+
+// Add includes the value v in the statistics.
+func (st *Statistics) Add(v float64) {
+	st.count++
+	st.sum += v
+	if st.count == 1 || v < st.min {
+		st.min = v
+	}
+	if st.count == 1 || v > st.max {
+		st.max = v
+	}
+
+	// Welford's algorithm keeps the variance numerically stable.
+	delta := v - st.mean
+	st.mean += delta / float64(st.count)
+	st.m2 += delta * (v - st.mean)
+
+	st.values = append(st.values, v)
+	st.sorted = false
+}
+
+// Count returns the total number of values.
+func (st *Statistics) Count() int {
+	return st.count
+}
+
+// Sum returns the sum of all the values.
+func (st *Statistics) Sum() float64 {
+	return st.sum
+}
+
+// Min returns the smallest value.
+func (st *Statistics) Min() float64 {
+	return st.min
+}
+
+// Max returns the largest value.
+func (st *Statistics) Max() float64 {
+	return st.max
+}
+
+// Mean returns the arithmetic mean of all the values.
+func (st *Statistics) Mean() float64 {
+	if st.count == 0 {
+		return math.NaN()
+	}
+	return st.mean
+}
+
+// Variance returns the population variance of all the values.
+func (st *Statistics) Variance() float64 {
+	if st.count == 0 {
+		return math.NaN()
+	}
+	return st.m2 / float64(st.count)
+}
+
+// StdDev returns the population standard deviation of all the values.
+func (st *Statistics) StdDev() float64 {
+	return math.Sqrt(st.Variance())
+}
+
+// Percentile returns the exact p-th percentile, where p is between 0 and
+// 100. When the percentile falls between two values, the result is linearly
+// interpolated between them.
+func (st *Statistics) Percentile(p float64) float64 {
+	if st.count == 0 || p < 0 || p > 100 {
+		return math.NaN()
+	}
+
+	st.sortValues()
+	rank := p / 100 * float64(st.count-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	weight := rank - float64(lower)
+	return st.values[lower]*(1-weight) + st.values[upper]*weight
+}
+
+// Equals checks and returns 'true' if m is a Statistics that has been
+// built from the same values.
+func (st *Statistics) Equals(m Model) bool {
+	other, ok := m.(*Statistics)
+	if !ok || other == nil || st.count != other.count {
+		return false
+	}
+
+	st.sortValues()
+	other.sortValues()
+	for i, v := range st.values {
+		if v != other.values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortValues sorts the values when new values were added since the
+// last time they were sorted.
+func (st *Statistics) sortValues() {
+	if !st.sorted {
+		sort.Float64s(st.values)
+		st.sorted = true
+	}
+}
+
+// Summarizing builds a collector that uses the extractor to get a numeric
+// value from every element, and summarizes all the values into Statistics.
+func Summarizing(extractor NumericExtractor) Collector[Model, *Statistics, *Statistics] {
+	accumulator := func(supp *Statistics, model Model) *Statistics {
+		supp.Add(extractor(model))
+		return supp
+	}
+
+	return NewCollector(NewStatistics, accumulator, basicFinisher[*Statistics])
+}
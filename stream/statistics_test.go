@@ -0,0 +1,67 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"math"
+	"testing"
+)
+
+func toFloat(m Model) float64 {
+	return float64(m.(ModelInt))
+}
+
+func TestSummarizing(t *testing.T) {
+	values := ModelSlice{ModelInt(2), ModelInt(4), ModelInt(4), ModelInt(4), ModelInt(5), ModelInt(5), ModelInt(7), ModelInt(9)}
+	stats := Collect(createStream(values), Summarizing(toFloat))
+
+	type test struct {
+		name string
+		got  float64
+		want float64
+	}
+
+	table := []test{
+		{name: "Count", got: float64(stats.Count()), want: 8},
+		{name: "Sum", got: stats.Sum(), want: 40},
+		{name: "Min", got: stats.Min(), want: 2},
+		{name: "Max", got: stats.Max(), want: 9},
+		{name: "Mean", got: stats.Mean(), want: 5},
+		{name: "Variance", got: stats.Variance(), want: 4},
+		{name: "StdDev", got: stats.StdDev(), want: 2},
+		{name: "Percentile 0", got: stats.Percentile(0), want: 2},
+		{name: "Percentile 50", got: stats.Percentile(50), want: 4.5},
+		{name: "Percentile 100", got: stats.Percentile(100), want: 9},
+	}
+
+	for _, te := range table {
+		if math.Abs(te.got-te.want) > 1e-9 {
+			t.Errorf("%v expected %v but got %v.", te.name, te.want, te.got)
+		}
+	}
+}
+
+func TestSummarizing_Empty(t *testing.T) {
+	stats := Collect(createStream(ModelSlice{}), Summarizing(toFloat))
+
+	if stats.Count() != 0 || stats.Sum() != 0 {
+		t.Error("An empty summary should have a count and sum of zero.")
+	}
+
+	if !math.IsNaN(stats.Mean()) || !math.IsNaN(stats.Min()) || !math.IsNaN(stats.Percentile(50)) {
+		t.Error("An empty summary should return NaN for undefined statistics.")
+	}
+}
+
+func TestStatistics_Equals(t *testing.T) {
+	s1 := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(2)}), Summarizing(toFloat))
+	s2 := Collect(createStream(ModelSlice{ModelInt(2), ModelInt(1)}), Summarizing(toFloat))
+	s3 := Collect(createStream(ModelSlice{ModelInt(1), ModelInt(3)}), Summarizing(toFloat))
+
+	if !s1.Equals(s2) {
+		t.Error("Statistics built from the same values should be equal.")
+	}
+
+	if s1.Equals(s3) {
+		t.Error("Statistics built from different values should not be equal.")
+	}
+}
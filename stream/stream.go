@@ -1,6 +1,10 @@
 package stream
 
 import (
+	"context"
+	"sort"
+	"sync"
+
 	. "github.com/Mathew-Estafanous/funGo/model"
 	. "github.com/Mathew-Estafanous/funGo/optional"
 )
@@ -14,12 +18,74 @@ import (
 // First is the Creation, which involves generating a Stream usually
 // using a given ModelSlice or by providing a channel. If you provide a channel,
 // you are responsible for closing it when finished.
+//
+// Sources that can fail, such as FromLines, report their error through Err
+// once the terminal operation has finished. Every terminal operation closes
+// the stream when it returns, which stops any source that is still producing
+// models, even when the operation finished early like FindFirst.
+//
+// Each operation only asks the stream before it for a model once the next
+// one has asked it for a model, so sources do not produce models ahead of
+// the terminal operation that uses them.
 type Stream struct {
-	ch chan Model
+	ch     chan Model
+	done   *signal
+	state  *state
+	demand *demand
 }
 
 type Consumer func(m Model)
 
+// signal is closed once the models of a stream are no longer needed.
+// Closing a signal also closes the signal of every stream before it in
+// the pipeline, so that cancellation always travels back to the source.
+type signal struct {
+	ch     chan struct{}
+	once   sync.Once
+	parent *signal
+}
+
+func newSignal(parent *signal) *signal {
+	return &signal{ch: make(chan struct{}), parent: parent}
+}
+
+func (sg *signal) close() {
+	if sg == nil {
+		return
+	}
+	sg.once.Do(func() { close(sg.ch) })
+	sg.parent.close()
+}
+
+// demand lets the producer of a stream wait until its consumer asks for
+// another model, so that models are only produced once they are needed.
+// Every receive requests a model first, and every send uses up a request.
+type demand struct {
+	ch chan struct{}
+	// held is only used by the producer, and is 'true' while it has taken a
+	// request that has not yet been answered by a send.
+	held bool
+}
+
+func newDemand() *demand {
+	return &demand{ch: make(chan struct{}, 1)}
+}
+
+// state is shared by every stream within the same pipeline and holds the
+// first error that was reported by any of them.
+type state struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (st *state) setErr(err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.err == nil {
+		st.err = err
+	}
+}
+
 // NewStream creates and returns a new stream struct that contains the
 // passed in channel.
 //
@@ -27,23 +93,176 @@ type Consumer func(m Model)
 // of the method and not the method itself.
 func NewStream(c chan Model) Stream {
 	return Stream{
-		ch: c,
+		ch:     c,
+		done:   newSignal(nil),
+		state:  &state{},
+		demand: newDemand(),
 	}
 }
 
 // NewStreamFromSlice takes a model slice and generates a stream containing
 // all the Models that were within that slice.
 func NewStreamFromSlice(slice ModelSlice) Stream {
-	openChan := make(chan Model)
+	return newSource(func(send func(Model) bool) error {
+		for _, model := range slice {
+			if !send(model) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// newSource creates a Stream whose models are produced by the given function
+// in its own goroutine. The send function returns false once the stream has
+// been cancelled, at which point the source should release its resources and
+// return. An error returned by the source is reported by Err.
+func newSource(produce func(send func(Model) bool) error) Stream {
+	s := NewStream(make(chan Model))
 
 	go func() {
-		defer close(openChan)
-		for _, model := range slice {
-			openChan <- model
+		defer close(s.ch)
+		if err := produce(s.send); err != nil {
+			s.state.setErr(err)
 		}
 	}()
 
-	return NewStream(openChan)
+	return s
+}
+
+// pipe creates the next Stream in the pipeline. The stage runs in its own
+// goroutine and reads the models of s, passing them on with next.send.
+// Once the stage returns, s is closed so that earlier streams stop as well.
+func (s Stream) pipe(stage func(next Stream)) Stream {
+	next := Stream{
+		ch:     make(chan Model),
+		done:   newSignal(s.done),
+		state:  s.state,
+		demand: newDemand(),
+	}
+
+	go func() {
+		defer close(next.ch)
+		defer s.Close()
+		stage(next)
+	}()
+
+	return next
+}
+
+// send passes the model on to the stream once its consumer has asked for
+// it, and returns false, without sending, if the stream has been cancelled.
+func (s Stream) send(m Model) bool {
+	if s.cancelled() || !s.ready() {
+		return false
+	}
+
+	select {
+	case s.ch <- m:
+		if s.demand != nil {
+			s.demand.held = false
+		}
+		return true
+	case <-s.done.ch:
+		return false
+	}
+}
+
+// ready waits until the consumer of s has asked for another model, and
+// returns false if s is cancelled first.
+func (s Stream) ready() bool {
+	if s.demand == nil || s.demand.held {
+		return true
+	}
+
+	select {
+	case <-s.demand.ch:
+		s.demand.held = true
+		return !s.cancelled()
+	case <-s.done.ch:
+		return false
+	}
+}
+
+// request asks the producer of s for another model.
+func (s Stream) request() {
+	if s.demand == nil {
+		return
+	}
+	select {
+	case s.demand.ch <- struct{}{}:
+	default:
+	}
+}
+
+// recv asks for the next model of s and waits for it. The bool is 'false'
+// once s has no more models.
+func (s Stream) recv() (Model, bool) {
+	s.request()
+	m, ok := <-s.ch
+	return m, ok
+}
+
+// pull receives the next model of s for the stage that produces next, once
+// the consumer of next has asked for a model. This keeps each stage from
+// reading ahead of what is needed further down the pipeline.
+func (s Stream) pull(next Stream) (Model, bool) {
+	if !next.ready() {
+		return nil, false
+	}
+	return s.recv()
+}
+
+// cancelled returns 'true' if the stream has been cancelled.
+func (s Stream) cancelled() bool {
+	select {
+	case <-s.done.ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close cancels the stream and every stream before it in the pipeline. Any
+// source that is still producing models will stop and release its resources.
+// Closing a stream more than once has no effect.
+//
+// Terminal operations close the stream when they return, so Close only needs
+// to be called when a stream is abandoned before reaching a terminal operation.
+func (s Stream) Close() {
+	s.done.close()
+}
+
+// Err returns the first error that was reported by a source or operation
+// within the stream's pipeline. It should be checked once the terminal
+// operation has returned.
+func (s Stream) Err() error {
+	if s.state == nil {
+		return nil
+	}
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	return s.state.err
+}
+
+// WithContext returns a Stream that stops passing on models once the context
+// is done. The stream is then cancelled, and the context's error is reported
+// by Err.
+func (s Stream) WithContext(ctx context.Context) Stream {
+	return s.pipe(func(next Stream) {
+		for next.ready() {
+			s.request()
+			select {
+			case <-ctx.Done():
+				next.state.setErr(ctx.Err())
+				return
+			case m, ok := <-s.ch:
+				if !ok || !next.send(m) {
+					return
+				}
+			}
+		}
+	})
 }
 
 // Filter takes in a Predicate and uses it to filter out all models that do not
@@ -51,51 +270,62 @@ func NewStreamFromSlice(slice ModelSlice) Stream {
 // will be passed on to the next stream. If it is false, then it will not be sent
 // to the next stream.
 func (s Stream) Filter(pred Predicate) Stream {
-	nextChan := make(chan Model)
-
-	go func() {
-		defer close(nextChan)
-		for model := range s.ch {
-			if pred(model) {
-				nextChan <- model
+	return s.pipe(func(next Stream) {
+		for model, ok := s.pull(next); ok; model, ok = s.pull(next) {
+			if pred(model) && !next.send(model) {
+				return
 			}
 		}
-	}()
-
-	return NewStream(nextChan)
+	})
 }
 
 // Map takes in an Operator and returns a Stream that contains the list of
 // models that the operator was used on.
 func (s Stream) Map(op Operator) Stream {
-	nextChan := make(chan Model)
-
-	go func() {
-		defer close(nextChan)
-		for model := range s.ch {
-			nextChan <- op(model)
+	return s.pipe(func(next Stream) {
+		for model, ok := s.pull(next); ok; model, ok = s.pull(next) {
+			if !next.send(op(model)) {
+				return
+			}
 		}
-	}()
-
-	return NewStream(nextChan)
+	})
 }
 
 // FlatMap applies and returns a Stream of models that have applied the
 // MultiOperator to each given model. This acts as a one to many
 // relationship operation that converts one Model into several models.
 func (s Stream) FlatMap(multiOp MultiOperator) Stream {
-	nextChan := make(chan Model)
-
-	go func() {
-		defer close(nextChan)
-		for model := range s.ch {
+	return s.pipe(func(next Stream) {
+		for model, ok := s.pull(next); ok; model, ok = s.pull(next) {
 			for _, m := range multiOp(model) {
-				nextChan <- m
+				if !next.send(m) {
+					return
+				}
 			}
 		}
-	}()
+	})
+}
 
-	return NewStream(nextChan)
+// Zip returns a Stream of ModelPairs, where each pair holds the next model
+// of s and the next model of other. The stream ends once either stream has
+// no more models, at which point both streams are closed. An error reported
+// by other is also reported by the returned stream.
+func (s Stream) Zip(other Stream) Stream {
+	return s.pipe(func(next Stream) {
+		defer func() {
+			other.Close()
+			if err := other.Err(); err != nil {
+				next.state.setErr(err)
+			}
+		}()
+
+		for first, ok := s.pull(next); ok; first, ok = s.pull(next) {
+			second, more := other.recv()
+			if !more || !next.send(NewModelPair(first, second)) {
+				return
+			}
+		}
+	})
 }
 
 // Limit takes in a given maximum and limits the number of models that
@@ -103,58 +333,44 @@ func (s Stream) FlatMap(multiOp MultiOperator) Stream {
 // number of elements that does not exceed the maximum limit.
 //
 // If the limit is already greater than the initial stream, then that
-// stream will remain unchanged.
+// stream will remain unchanged. Once the limit is reached, the earlier
+// streams are closed so that no more models are produced.
 func (s Stream) Limit(max int) Stream {
-	nextChan := make(chan Model)
+	return s.pipe(func(next Stream) {
+		if max <= 0 {
+			return
+		}
 
-	go func() {
-		defer close(nextChan)
 		count := 0
-		for m := range s.ch {
+		for m, ok := s.pull(next); ok; m, ok = s.pull(next) {
+			count++
 			if count >= max {
+				s.Close()
+				next.send(m)
+				return
+			}
+			if !next.send(m) {
 				return
 			}
-			nextChan <- m
-			count++
 		}
-	}()
-
-	return NewStream(nextChan)
+	})
 }
 
 // Distinct alters the given stream by removing all duplicate elements
 // and ensuring that the stream does not contain any equal values.
 // If there are no duplicates, then the stream should remain unaltered.
+//
+// Models are compared using their hash and Equals, so any Model can be
+// made distinct, including a ModelSlice or ModelMap.
 func (s Stream) Distinct() Stream {
-	var modelList ModelSlice
-	for m := range s.ch {
-		if contains(modelList, m) {
-			continue
-		}
-		modelList = append(modelList, m)
-	}
-
-	nextChan := make(chan Model)
-
-	go func() {
-		defer close(nextChan)
-		for _, k := range modelList {
-			nextChan <- k
-		}
-	}()
-
-	return NewStream(nextChan)
-}
-
-// contains is an unexported method that Distinct() when checking
-// that there are no duplicates in the given ModelSlice.
-func contains(slice ModelSlice, m Model) bool {
-	for _, v := range slice {
-		if v.Equals(m) {
-			return true
+	return s.pipe(func(next Stream) {
+		seen := NewHashSet()
+		for m, ok := s.pull(next); ok; m, ok = s.pull(next) {
+			if seen.Add(m) && !next.send(m) {
+				return
+			}
 		}
-	}
-	return false
+	})
 }
 
 // Peek is an operation that uses a consumer to peek into the given
@@ -165,28 +381,55 @@ func contains(slice ModelSlice, m Model) bool {
 // unlike this.
 func (s Stream) Peek(consumer Consumer) Stream {
 	var modelList ModelSlice
-	for m := range s.ch {
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
 		consumer(m)
 		modelList = append(modelList, m)
 	}
 
-	nexChan := make(chan Model)
-
-	go func() {
-		defer close(nexChan)
+	return s.pipe(func(next Stream) {
 		for _, v := range modelList {
-			nexChan <- v
+			if !next.send(v) {
+				return
+			}
 		}
-	}()
+	})
+}
 
-	return NewStream(nexChan)
+// Sorted returns a Stream that contains the same models sorted by their
+// natural order. Every model must implement the Ordered interface. All the
+// models must be read before the first can be passed on.
+func (s Stream) Sorted() Stream {
+	return s.SortedBy(NaturalOrder)
+}
+
+// SortedBy returns a Stream that contains the same models sorted using the
+// comparator. Models that compare as equal are kept in their original order.
+func (s Stream) SortedBy(cmp Comparator) Stream {
+	cmp = orNatural(cmp)
+
+	return s.pipe(func(next Stream) {
+		var models ModelSlice
+		for m, ok := s.pull(next); ok; m, ok = s.pull(next) {
+			models = append(models, m)
+		}
+
+		sort.SliceStable(models, func(i, j int) bool {
+			return cmp(models[i], models[j]) < 0
+		})
+		for _, m := range models {
+			if !next.send(m) {
+				return
+			}
+		}
+	})
 }
 
 // AnyMatch is a terminating process that uses a given predicate to
 // check if the predicate is true on any of the models. If it matches
 // with any of the models, then the entire process will return true.
 func (s Stream) AnyMatch(predicate Predicate) bool {
-	for m := range s.ch {
+	defer s.Close()
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
 		if predicate(m) {
 			return true
 		}
@@ -199,7 +442,8 @@ func (s Stream) AnyMatch(predicate Predicate) bool {
 // end up returning false. If all models match the predicate then the
 // return bool will be true.
 func (s Stream) AllMatch(predicate Predicate) bool {
-	for m := range s.ch {
+	defer s.Close()
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
 		if !predicate(m) {
 			return false
 		}
@@ -211,7 +455,8 @@ func (s Stream) AllMatch(predicate Predicate) bool {
 // result to AllMatch. Returning true if all the models do not match
 // the predicate and false if any of the models match the predicate.
 func (s Stream) NoneMatch(predicate Predicate) bool {
-	for m := range s.ch {
+	defer s.Close()
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
 		if predicate(m) {
 			return false
 		}
@@ -219,50 +464,85 @@ func (s Stream) NoneMatch(predicate Predicate) bool {
 	return true
 }
 
-func (s Stream) FindFirst(predicate Predicate) Optional {
-	for m := range s.ch {
+// FindFirst is a terminating process that returns an Optional containing
+// the first model that matches the predicate. If no model matches, then
+// an empty Optional is returned.
+func (s Stream) FindFirst(predicate Predicate) Optional[Model] {
+	defer s.Close()
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
 		if predicate(m) {
 			return OptionalOf(m)
 		}
 	}
-	return OptionalEmpty()
+	return OptionalEmpty[Model]()
+}
+
+// Min is a terminating process that returns an Optional containing the
+// smallest model by natural order. An empty stream returns an empty Optional.
+func (s Stream) Min() Optional[Model] {
+	return s.MinBy(NaturalOrder)
+}
+
+// MinBy is a terminating process that returns an Optional containing the
+// smallest model according to the comparator. When several models are the
+// smallest, the first one is returned.
+func (s Stream) MinBy(cmp Comparator) Optional[Model] {
+	cmp = orNatural(cmp)
+	return s.reduceBest(func(m, best Model) bool { return cmp(m, best) < 0 })
+}
+
+// Max is a terminating process that returns an Optional containing the
+// largest model by natural order. An empty stream returns an empty Optional.
+func (s Stream) Max() Optional[Model] {
+	return s.MaxBy(NaturalOrder)
+}
+
+// MaxBy is a terminating process that returns an Optional containing the
+// largest model according to the comparator. When several models are the
+// largest, the first one is returned.
+func (s Stream) MaxBy(cmp Comparator) Optional[Model] {
+	cmp = orNatural(cmp)
+	return s.reduceBest(func(m, best Model) bool { return cmp(m, best) > 0 })
+}
+
+// reduceBest returns the model that is better than every model after it.
+func (s Stream) reduceBest(better func(m, best Model) bool) Optional[Model] {
+	defer s.Close()
+	var best Model
+	found := false
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
+		if !found || better(m, best) {
+			best, found = m, true
+		}
+	}
+
+	if !found {
+		return OptionalEmpty[Model]()
+	}
+	return OptionalOf(best)
 }
 
 // Count takes in the Stream and gets the total number of models that
 // are remaining in the given Stream. This is a terminal operation and
 // will return the count as an int.
 func (s Stream) Count() int {
+	defer s.Close()
 	count := 0
-	for range s.ch {
+	for _, ok := s.recv(); ok; _, ok = s.recv() {
 		count++
 	}
 	return count
 }
 
-// Collect is an important terminal operator that allows flexibility in
-// in outlining how the stream should be grouped and collected. Note that
-// the method returns 'interface{}' which allows for any type beyond just
-// Models.
-func (s Stream) Collect(collector Collector) interface{} {
-	result := collector.supplier()
-
-	for m := range s.ch {
-		result = collector.accumulator(result, m)
-	}
-
-	result = collector.finisher(result)
-
-	return result
-}
-
 // ForEach is a terminating process that does return anything. For each
 // Model in the stream, the Consumer will be called on that model.
 func (s Stream) ForEach(consumer Consumer) {
 	if s.ch == nil {
 		return
 	}
+	defer s.Close()
 
-	for m := range s.ch {
+	for m, ok := s.recv(); ok; m, ok = s.recv() {
 		consumer(m)
 	}
 }
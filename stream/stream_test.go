@@ -2,6 +2,7 @@ package stream
 
 import (
 	. "github.com/Mathew-Estafanous/funGo/model"
+	"strings"
 	"testing"
 )
 
@@ -15,7 +16,7 @@ func createStream(slice ModelSlice) Stream {
 		}
 	}()
 
-	return Stream{openChannel}
+	return NewStream(openChannel)
 }
 
 func TestNewStream(t *testing.T) {
@@ -31,7 +32,7 @@ func TestNewStream(t *testing.T) {
 	}()
 
 	for _, m := range testValues {
-		if model := <-stream.ch; !model.Equals(m) {
+		if model, _ := stream.recv(); !model.Equals(m) {
 			t.Error("New Stream did not create a stream with the correct channel.")
 		}
 	}
@@ -44,7 +45,7 @@ func TestNewStreamFromSlice(t *testing.T) {
 	stream := NewStreamFromSlice(testSlice)
 
 	for _, model := range testSlice {
-		if m := <-stream.ch; !m.Equals(model) {
+		if m, _ := stream.recv(); !m.Equals(model) {
 			t.Error("New Stream from slice did not create a stream with the correct channel values.")
 		}
 	}
@@ -71,7 +72,7 @@ func TestStream_Filter(t *testing.T) {
 		Filter(filterTest.predicate)
 
 	for _, model := range filterTest.want {
-		if m := <-result.ch; !m.Equals(model) {
+		if m, _ := result.recv(); !m.Equals(model) {
 			t.Error(filterTest.error)
 		}
 	}
@@ -98,7 +99,7 @@ func TestStream_Map(t *testing.T) {
 		Map(mapTest.operator)
 
 	for _, model := range mapTest.want {
-		if m := <-result.ch; !m.Equals(model) {
+		if m, _ := result.recv(); !m.Equals(model) {
 			t.Error(mapTest.error)
 		}
 	}
@@ -123,7 +124,7 @@ func TestStream_FlatMap(t *testing.T) {
 
 	result := createStream(flatMapTest.values).FlatMap(flatMapTest.operator)
 	index := 0
-	for m := range result.ch {
+	for m, ok := result.recv(); ok; m, ok = result.recv() {
 		if !flatMapTest.result[index].Equals(m) {
 			t.Error(flatMapTest.error)
 		}
@@ -157,7 +158,7 @@ func TestStream_Limit(t *testing.T) {
 	for _, te := range limitTest {
 		result := createStream(te.values).Limit(te.limit)
 		count := 0
-		for m := range result.ch {
+		for m, ok := result.recv(); ok; m, ok = result.recv() {
 			if !m.Equals(te.want[count]) {
 				t.Error(te.name)
 			}
@@ -170,6 +171,37 @@ func TestStream_Limit(t *testing.T) {
 	}
 }
 
+func TestStream_Zip(t *testing.T) {
+	type test struct {
+		error  string
+		first  ModelSlice
+		second ModelSlice
+		want   ModelSlice
+	}
+
+	zipTests := []test{
+		{
+			error:  "Streams of the same length should be zipped into pairs.",
+			first:  ModelSlice{ModelInt(1), ModelInt(2)},
+			second: ModelSlice{ModelString("a"), ModelString("b")},
+			want:   ModelSlice{NewModelPair(ModelInt(1), ModelString("a")), NewModelPair(ModelInt(2), ModelString("b"))},
+		},
+		{
+			error:  "The zipped stream should end once the shorter stream ends.",
+			first:  ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)},
+			second: ModelSlice{ModelString("a")},
+			want:   ModelSlice{NewModelPair(ModelInt(1), ModelString("a"))},
+		},
+	}
+
+	for _, te := range zipTests {
+		result := Collect(NewStreamFromSlice(te.first).Zip(NewStreamFromSlice(te.second)), ToSlice())
+		if !result.Equals(te.want) {
+			t.Errorf("%v Expected %v but got %v.", te.error, te.want, result)
+		}
+	}
+}
+
 func TestStream_Distinct(t *testing.T) {
 	type test struct {
 		error string
@@ -188,12 +220,17 @@ func TestStream_Distinct(t *testing.T) {
 			value: ModelSlice{ModelInt(1), ModelInt(2)},
 			want:  ModelSlice{ModelInt(1), ModelInt(2)},
 		},
+		{
+			error: "Duplicate slices should be removed from the stream.",
+			value: ModelSlice{ModelSlice{ModelInt(1)}, ModelSlice{ModelInt(2)}, ModelSlice{ModelInt(1)}},
+			want:  ModelSlice{ModelSlice{ModelInt(1)}, ModelSlice{ModelInt(2)}},
+		},
 	}
 
 	for _, te := range distinctTests {
 		result := createStream(te.value).Distinct()
 		index := 0
-		for m := range result.ch {
+		for m, ok := result.recv(); ok; m, ok = result.recv() {
 			if !m.Equals(te.want[index]) {
 				t.Error(te.error)
 			}
@@ -202,6 +239,22 @@ func TestStream_Distinct(t *testing.T) {
 	}
 }
 
+// caseInsensitive has a custom Equals but no Hash method.
+type caseInsensitive string
+
+func (c caseInsensitive) Equals(m Model) bool {
+	other, ok := m.(caseInsensitive)
+	return ok && strings.EqualFold(string(c), string(other))
+}
+
+func TestStream_DistinctWithoutHash(t *testing.T) {
+	result := Collect(createStream(ModelSlice{caseInsensitive("Bob"), caseInsensitive("bob")}).Distinct(), ToSlice())
+
+	if len(result) != 1 {
+		t.Errorf("Models that are equal by Equals should be removed, but got %v.", result)
+	}
+}
+
 func TestStream_Peek(t *testing.T) {
 	type test struct {
 		error    string
@@ -226,7 +279,7 @@ func TestStream_Peek(t *testing.T) {
 		t.Error(peekTest.error)
 	}
 	index := 0
-	for m := range result.ch {
+	for m, ok := result.recv(); ok; m, ok = result.recv() {
 		if !m.Equals(peekTest.value[index]) {
 			t.Error(peekTest.error)
 		}
@@ -404,23 +457,23 @@ func TestStream_Collect(t *testing.T) {
 	type test struct {
 		error     string
 		value     ModelSlice
-		collector Collector
+		collector Collector[Model, ModelSlice, ModelSlice]
 		want      ModelSlice
 	}
 
 	collectTest := test{
 		error: "When Collect is given a ToSlice collector it should transform the elements in the stream into a Slice.",
 		value: ModelSlice{ModelInt(1), ModelInt(3), ModelInt(4)},
-		collector: Collector{
-			supplier:    func() Model { return ModelSlice{} },
-			accumulator: func(m1, m2 Model) Model { return append(m1.(ModelSlice), m2) },
-			finisher:    func(m Model) Model { return m },
+		collector: Collector[Model, ModelSlice, ModelSlice]{
+			supplier:    func() ModelSlice { return ModelSlice{} },
+			accumulator: func(m1 ModelSlice, m2 Model) ModelSlice { return append(m1, m2) },
+			finisher:    func(m ModelSlice) ModelSlice { return m },
 		},
 		want: ModelSlice{ModelInt(1), ModelInt(3), ModelInt(4)},
 	}
 
-	result := createStream(collectTest.value).Collect(collectTest.collector)
-	if !result.(ModelSlice).Equals(collectTest.want) {
+	result := Collect(createStream(collectTest.value), collectTest.collector)
+	if !result.Equals(collectTest.want) {
 		t.Error(collectTest.error)
 	}
 }
@@ -449,3 +502,38 @@ func TestStream_ForEach(t *testing.T) {
 		t.Error(forEachTest.error)
 	}
 }
+
+func TestStream_Sorted(t *testing.T) {
+	result := Collect(createStream(ModelSlice{ModelInt(3), ModelInt(1), ModelInt(2)}).Sorted(), ToSlice())
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3)}) {
+		t.Errorf("Sorted expected models in natural order but got %v.", result)
+	}
+
+	byLength := func(m1, m2 Model) int { return len(m1.(ModelString)) - len(m2.(ModelString)) }
+	words := ModelSlice{ModelString("ccc"), ModelString("a"), ModelString("bb"), ModelString("d")}
+	result = Collect(createStream(words).SortedBy(byLength), ToSlice())
+	if !result.Equals(ModelSlice{ModelString("a"), ModelString("d"), ModelString("bb"), ModelString("ccc")}) {
+		t.Errorf("SortedBy expected a stable sort by the comparator but got %v.", result)
+	}
+}
+
+func TestStream_MinMax(t *testing.T) {
+	values := ModelSlice{ModelString("pear"), ModelString("apple"), ModelString("zucchini")}
+
+	if m, _ := createStream(values).Min().Get(); !ModelsEqual(m, ModelString("apple")) {
+		t.Errorf("Min expected apple but got %v.", m)
+	}
+
+	if m, _ := createStream(values).Max().Get(); !ModelsEqual(m, ModelString("zucchini")) {
+		t.Errorf("Max expected zucchini but got %v.", m)
+	}
+
+	byLength := func(m1, m2 Model) int { return len(m1.(ModelString)) - len(m2.(ModelString)) }
+	if m, _ := createStream(values).MinBy(byLength).Get(); !ModelsEqual(m, ModelString("pear")) {
+		t.Errorf("MinBy expected pear but got %v.", m)
+	}
+
+	if !createStream(ModelSlice{}).Max().IsEmpty() {
+		t.Error("Max of an empty stream should return an empty optional.")
+	}
+}
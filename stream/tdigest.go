@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"math"
+	"sort"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// centroid is a cluster of values within a TDigest that is summarized
+// by the mean of the values and the number of values it represents.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest is a Model that estimates quantiles of a stream of numeric values
+// while only keeping a small number of centroids in memory. It is useful for
+// large streams where keeping every value, like Statistics does, is not
+// acceptable.
+//
+// The compression controls the trade-off between accuracy and memory. A
+// higher compression keeps more centroids and gives more accurate estimates.
+// Estimates are most accurate for quantiles near 0 and 1.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+// NewTDigest creates and returns an empty TDigest with the given compression.
+// A compression of 100 is a reasonable default for most uses.
+func NewTDigest(compression float64) *TDigest {
+	if compression <= 0 {
+		compression = 100
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add includes the value v in the digest.
+func (td *TDigest) Add(v float64) {
+	td.buffer = append(td.buffer, centroid{mean: v, weight: 1})
+	td.count++
+	td.min = math.Min(td.min, v)
+	td.max = math.Max(td.max, v)
+
+	if len(td.buffer) >= int(td.compression)*5 {
+		td.compress()
+	}
+}
+
+// Count returns the total number of values that were added to the digest.
+func (td *TDigest) Count() int {
+	return int(td.count)
+}
+
+// Quantile returns the estimated value at the quantile q, where q is between
+// 0 and 1. NaN is returned when the digest is empty or q is out of range.
+func (td *TDigest) Quantile(q float64) float64 {
+	td.compress()
+	if len(td.centroids) == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if len(td.centroids) == 1 {
+		return td.centroids[0].mean
+	}
+
+	index := q * td.count
+	first := td.centroids[0]
+	if index < first.weight/2 {
+		return td.min + (first.mean-td.min)*index/(first.weight/2)
+	}
+
+	cumulative := 0.0
+	for i := 0; i < len(td.centroids)-1; i++ {
+		left, right := td.centroids[i], td.centroids[i+1]
+		leftCenter := cumulative + left.weight/2
+		rightCenter := cumulative + left.weight + right.weight/2
+		if index <= rightCenter {
+			t := (index - leftCenter) / (rightCenter - leftCenter)
+			return left.mean + t*(right.mean-left.mean)
+		}
+		cumulative += left.weight
+	}
+
+	last := td.centroids[len(td.centroids)-1]
+	lastCenter := td.count - last.weight/2
+	return last.mean + (td.max-last.mean)*(index-lastCenter)/(last.weight/2)
+}
+
+// Equals checks and returns 'true' if m is a TDigest that has the same
+// centroids as td.
+func (td *TDigest) Equals(m Model) bool {
+	other, ok := m.(*TDigest)
+	if !ok || other == nil || td.count != other.count {
+		return false
+	}
+
+	td.compress()
+	other.compress()
+	if len(td.centroids) != len(other.centroids) {
+		return false
+	}
+	for i, c := range td.centroids {
+		if c != other.centroids[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// compress merges all the buffered values into the centroids, making sure
+// that no centroid grows beyond the size allowed by the compression.
+func (td *TDigest) compress() {
+	if len(td.buffer) == 0 {
+		return
+	}
+
+	all := append(td.centroids, td.buffer...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(all))
+	current := all[0]
+	weightSoFar := 0.0
+	limit := td.quantileLimit(0)
+	for _, next := range all[1:] {
+		if (weightSoFar+current.weight+next.weight)/td.count <= limit {
+			current.weight += next.weight
+			current.mean += (next.mean - current.mean) * next.weight / current.weight
+			continue
+		}
+
+		merged = append(merged, current)
+		weightSoFar += current.weight
+		limit = td.quantileLimit(weightSoFar / td.count)
+		current = next
+	}
+
+	td.centroids = append(merged, current)
+	td.buffer = nil
+}
+
+// quantileLimit uses the k1 scale function to find the largest quantile a
+// centroid that starts at quantile q may grow to.
+func (td *TDigest) quantileLimit(q float64) float64 {
+	k := td.compression / (2 * math.Pi) * math.Asin(2*q-1)
+	if k+1 >= td.compression/4 {
+		return 1
+	}
+	return (math.Sin((k+1)*2*math.Pi/td.compression) + 1) / 2
+}
+
+// ApproxQuantiles builds a collector that uses the extractor to get a
+// numeric value from every element, and adds every value into a TDigest
+// with the given compression.
+func ApproxQuantiles(extractor NumericExtractor, compression float64) Collector[Model, *TDigest, *TDigest] {
+	supplier := func() *TDigest { return NewTDigest(compression) }
+
+	accumulator := func(supp *TDigest, model Model) *TDigest {
+		supp.Add(extractor(model))
+		return supp
+	}
+
+	return NewCollector(supplier, accumulator, basicFinisher[*TDigest])
+}
@@ -0,0 +1,50 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"math"
+	"testing"
+)
+
+func TestApproxQuantiles(t *testing.T) {
+	values := make(ModelSlice, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		// Spread the values so that they do not arrive in sorted order.
+		values = append(values, ModelInt((i*7919)%10000))
+	}
+
+	digest := Collect(createStream(values), ApproxQuantiles(toFloat, 100))
+
+	if digest.Count() != 10000 {
+		t.Errorf("Expected the digest to count 10000 values but got %v.", digest.Count())
+	}
+
+	for _, q := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+		want := q * 10000
+		if got := digest.Quantile(q); math.Abs(got-want) > 100 {
+			t.Errorf("Quantile %v expected to be close to %v but got %v.", q, want, got)
+		}
+	}
+
+	if len(digest.centroids) > 1000 {
+		t.Errorf("Expected the digest to be compressed but it holds %v centroids.", len(digest.centroids))
+	}
+}
+
+func TestTDigest_Quantile(t *testing.T) {
+	digest := NewTDigest(100)
+	if !math.IsNaN(digest.Quantile(0.5)) {
+		t.Error("An empty digest should return NaN.")
+	}
+
+	digest.Add(5)
+	if digest.Quantile(0.5) != 5 {
+		t.Errorf("A digest with a single value should return that value, got %v.", digest.Quantile(0.5))
+	}
+
+	digest.Add(1)
+	digest.Add(9)
+	if digest.Quantile(0) != 1 || digest.Quantile(1) != 9 {
+		t.Errorf("Expected the extreme quantiles to be 1 and 9 but got %v and %v.", digest.Quantile(0), digest.Quantile(1))
+	}
+}
@@ -0,0 +1,120 @@
+package stream
+
+import (
+	"container/heap"
+	"sort"
+
+	. "github.com/Mathew-Estafanous/funGo/model"
+)
+
+// BoundedHeap is the container used by TopK and BottomK. It holds at most k
+// models, dropping the lowest ranked one whenever a better model arrives.
+type BoundedHeap struct {
+	k    int
+	seen int
+	heap modelHeap
+}
+
+// heapEntry is a model held by a modelHeap, along with the order in which
+// it arrived.
+type heapEntry struct {
+	model Model
+	seq   int
+}
+
+// modelHeap is a heap whose root is always the model that would be dropped
+// first. Models that are tied by cmp are ranked by their arrival, so that
+// the earlier models are kept.
+type modelHeap struct {
+	cmp     Comparator
+	entries []heapEntry
+}
+
+// ranksAbove returns 'true' if a would be kept over b.
+func (h *modelHeap) ranksAbove(a, b heapEntry) bool {
+	if c := h.cmp(a.model, b.model); c != 0 {
+		return c > 0
+	}
+	return a.seq < b.seq
+}
+
+func (h *modelHeap) Len() int           { return len(h.entries) }
+func (h *modelHeap) Less(i, j int) bool { return h.ranksAbove(h.entries[j], h.entries[i]) }
+func (h *modelHeap) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *modelHeap) Push(x any)         { h.entries = append(h.entries, x.(heapEntry)) }
+
+func (h *modelHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// offer adds m to the heap, dropping the smallest model when the heap
+// would otherwise grow beyond k models.
+func (b *BoundedHeap) offer(m Model) {
+	h := &b.heap
+	if b.k <= 0 {
+		return
+	}
+	entry := heapEntry{model: m, seq: b.seen}
+	b.seen++
+	if h.Len() < b.k {
+		heap.Push(h, entry)
+		return
+	}
+	if h.ranksAbove(entry, h.entries[0]) {
+		h.entries[0] = entry
+		heap.Fix(h, 0)
+	}
+}
+
+// boundedCollector builds a collector that keeps the k largest models
+// according to cmp and returns them sorted from largest to smallest. Tied
+// models are kept and returned in the order they arrived. The comparator
+// must not be nil.
+func boundedCollector(k int, cmp Comparator) Collector[Model, *BoundedHeap, ModelSlice] {
+	supplier := func() *BoundedHeap {
+		return &BoundedHeap{k: k, heap: modelHeap{cmp: cmp}}
+	}
+
+	accumulator := func(supp *BoundedHeap, model Model) *BoundedHeap {
+		supp.offer(model)
+		return supp
+	}
+
+	finisher := func(supp *BoundedHeap) ModelSlice {
+		entries := make([]heapEntry, len(supp.heap.entries))
+		copy(entries, supp.heap.entries)
+		sort.Slice(entries, func(i, j int) bool {
+			return supp.heap.ranksAbove(entries[i], entries[j])
+		})
+
+		result := make(ModelSlice, len(entries))
+		for i, entry := range entries {
+			result[i] = entry.model
+		}
+		return result
+	}
+
+	return NewCollector(supplier, accumulator, finisher)
+}
+
+// TopK builds a collector that keeps the k largest elements according to
+// the comparator, and returns them in a ModelSlice sorted from largest to
+// smallest. No more than k elements are ever held in memory, so the whole
+// stream does not need to be sorted. Tied models are kept in the order they
+// arrived. A nil comparator uses the natural order.
+func TopK(k int, cmp Comparator) Collector[Model, *BoundedHeap, ModelSlice] {
+	return boundedCollector(k, orNatural(cmp))
+}
+
+// BottomK builds a collector that keeps the k smallest elements according to
+// the comparator, and returns them in a ModelSlice sorted from smallest to
+// largest. No more than k elements are ever held in memory. Tied models are
+// kept in the order they arrived. A nil comparator uses the natural order.
+func BottomK(k int, cmp Comparator) Collector[Model, *BoundedHeap, ModelSlice] {
+	cmp = orNatural(cmp)
+	return boundedCollector(k, func(m1, m2 Model) int {
+		return cmp(m2, m1)
+	})
+}
@@ -0,0 +1,103 @@
+package stream
+
+import (
+	. "github.com/Mathew-Estafanous/funGo/model"
+	"testing"
+)
+
+func compareInts(m1, m2 Model) int {
+	return int(m1.(ModelInt) - m2.(ModelInt))
+}
+
+func TestTopK(t *testing.T) {
+	type test struct {
+		name   string
+		values ModelSlice
+		k      int
+		cmp    Comparator
+		want   ModelSlice
+	}
+
+	byFirst := func(m1, m2 Model) int {
+		return compareInts(m1.(ModelPair).First, m2.(ModelPair).First)
+	}
+	var ties ModelSlice
+	for i := 0; i < 40; i++ {
+		ties = append(ties, NewModelPair(ModelInt(i%3), ModelInt(i)))
+	}
+
+	table := []test{
+		{
+			name:   "The 3 largest models should be returned from largest to smallest.",
+			values: ModelSlice{ModelInt(5), ModelInt(1), ModelInt(9), ModelInt(3), ModelInt(7)},
+			k:      3,
+			want:   ModelSlice{ModelInt(9), ModelInt(7), ModelInt(5)},
+		},
+		{
+			name:   "A stream smaller than k should return every model sorted.",
+			values: ModelSlice{ModelInt(2), ModelInt(8)},
+			k:      5,
+			want:   ModelSlice{ModelInt(8), ModelInt(2)},
+		},
+		{
+			name:   "A k of zero should return an empty slice.",
+			values: ModelSlice{ModelInt(2), ModelInt(8)},
+			k:      0,
+			want:   ModelSlice{},
+		},
+		{
+			name:   "Tied models should be kept and returned in the order they arrived.",
+			values: ties,
+			k:      15,
+			cmp:    byFirst,
+			want: ModelSlice{
+				ties[2], ties[5], ties[8], ties[11], ties[14], ties[17], ties[20],
+				ties[23], ties[26], ties[29], ties[32], ties[35], ties[38], ties[1], ties[4],
+			},
+		},
+	}
+
+	for _, te := range table {
+		cmp := te.cmp
+		if cmp == nil {
+			cmp = compareInts
+		}
+		result := Collect(createStream(te.values), TopK(te.k, cmp))
+		if !result.Equals(te.want) {
+			t.Errorf("%v Got %v.", te.name, result)
+		}
+	}
+}
+
+func TestBottomK(t *testing.T) {
+	values := ModelSlice{ModelInt(5), ModelInt(1), ModelInt(9), ModelInt(3), ModelInt(7)}
+	result := Collect(createStream(values), BottomK(2, compareInts))
+
+	if !result.Equals(ModelSlice{ModelInt(1), ModelInt(3)}) {
+		t.Errorf("BottomK expected the 2 smallest models from smallest to largest but got %v.", result)
+	}
+}
+
+func TestTopK_GroupingBy(t *testing.T) {
+	parity := func(m Model) Model { return m.(ModelInt) % 2 }
+	values := ModelSlice{ModelInt(1), ModelInt(2), ModelInt(3), ModelInt(4), ModelInt(5), ModelInt(6), ModelInt(8)}
+
+	result := Collect(createStream(values), GroupingBy(parity, TopK(2, compareInts)))
+
+	expectedMap := ModelMap{
+		ModelInt(0): ModelSlice{ModelInt(8), ModelInt(6)},
+		ModelInt(1): ModelSlice{ModelInt(5), ModelInt(3)},
+	}
+
+	if !result.Equals(expectedMap) {
+		t.Errorf("TopK as a downstream collector expected %v but got %v.", expectedMap, result)
+	}
+}
+
+func TestTopK_NaturalOrder(t *testing.T) {
+	result := Collect(createStream(ModelSlice{ModelInt(2), ModelInt(9), ModelInt(4)}), TopK(2, nil))
+
+	if !result.Equals(ModelSlice{ModelInt(9), ModelInt(4)}) {
+		t.Errorf("TopK with no comparator expected natural order but got %v.", result)
+	}
+}